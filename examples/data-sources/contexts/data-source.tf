@@ -0,0 +1,19 @@
+locals {
+  // replace with your organization ID
+  org_id = "7f284df8-ac74-42d5-9fad-ab23f731e475"
+}
+
+data "circleci_contexts" "deploy" {
+  owner = {
+    id   = local.org_id
+    type = "organization"
+  }
+  name_regex           = "^deploy-"
+  include_env_vars     = true
+  include_restrictions = true
+}
+
+# deploy contexts that anyone in the organization can use
+output "unrestricted_deploy_contexts" {
+  value = [for c in data.circleci_contexts.deploy.contexts : c.name if length(c.restrictions) == 0]
+}
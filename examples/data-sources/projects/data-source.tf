@@ -0,0 +1,14 @@
+data "circleci_projects" "services" {
+  organization_slug = "gh/acme"
+  name_regex        = "-service$"
+  followed          = true
+}
+
+# add the same environment variable to every matching project
+resource "circleci_env_var" "sentry_dsn" {
+  for_each = { for p in data.circleci_projects.services.projects : p.slug => p }
+
+  project_slug = each.value.slug
+  name         = "SENTRY_DSN"
+  value        = "https://examplePublicKey@o0.ingest.sentry.io/0"
+}
@@ -0,0 +1,48 @@
+resource "circleci_runner_resource_class" "machine_linux" {
+  resource_class = "kelvintaywl-tf/machine-linux"
+  description    = "Amazon Linux 2"
+}
+
+resource "circleci_runner_token" "machine_linux" {
+  resource_class = circleci_runner_resource_class.machine_linux.resource_class
+  nickname       = "main"
+}
+
+# launch-agent-config.yaml for machine runners
+data "circleci_runner_agent_config" "machine_linux" {
+  type                      = "machine"
+  resource_class            = circleci_runner_resource_class.machine_linux.resource_class
+  token                     = circleci_runner_token.machine_linux.token
+  working_directory         = "/var/opt/circleci/workdir"
+  cleanup_working_directory = true
+  max_run_time              = "5h"
+}
+
+resource "circleci_runner_resource_class" "k8s" {
+  resource_class = "kelvintaywl-tf/k8s"
+  description    = "container agent"
+}
+
+resource "circleci_runner_token" "k8s" {
+  resource_class = circleci_runner_resource_class.k8s.resource_class
+  nickname       = "main"
+}
+
+# values.yaml for the container agent Helm chart
+data "circleci_runner_agent_config" "k8s" {
+  type           = "container"
+  resource_class = circleci_runner_resource_class.k8s.resource_class
+  token          = circleci_runner_token.k8s.token
+  max_run_time   = "5h"
+  container_resource_limits = {
+    cpu    = "1"
+    memory = "2Gi"
+  }
+}
+
+resource "helm_release" "container_agent" {
+  name       = "container-agent"
+  repository = "https://packagecloud.io/circleci/container-agent/helm"
+  chart      = "container-agent"
+  values     = [data.circleci_runner_agent_config.k8s.rendered]
+}
@@ -0,0 +1,16 @@
+data "circleci_runner_instances" "machine_linux" {
+  resource_class = "kelvintaywl-tf/machine-linux"
+}
+
+output "instances" {
+  description = "connected runner agents"
+  value       = data.circleci_runner_instances.machine_linux.instances
+}
+
+# fails the apply if no agents are connected for the resource-class
+check "runner_agents_connected" {
+  assert {
+    condition     = length(data.circleci_runner_instances.machine_linux.instances) > 0
+    error_message = "No runner agents are connected for kelvintaywl-tf/machine-linux"
+  }
+}
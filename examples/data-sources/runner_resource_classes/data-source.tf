@@ -6,3 +6,13 @@ output "resources" {
   description = "runner resource-classes"
   value       = data.circleci_runner_resource_classes.tf.resource_classes
 }
+
+data "circleci_runner_resource_classes" "machine_linux" {
+  namespace = "kelvintaywl-tf"
+  name      = "machine-linux"
+}
+
+output "machine_linux_id" {
+  description = "runner resource-class ID"
+  value       = data.circleci_runner_resource_classes.machine_linux.resource_class_id
+}
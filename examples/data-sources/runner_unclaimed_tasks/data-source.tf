@@ -0,0 +1,12 @@
+data "circleci_runner_unclaimed_tasks" "machine_linux" {
+  resource_class = "kelvintaywl-tf/machine-linux"
+}
+
+# e.g., size an AWS Auto Scaling group of machine runners by the pending work
+resource "aws_autoscaling_group" "machine_linux" {
+  # ...
+  desired_capacity = min(
+    10,
+    data.circleci_runner_unclaimed_tasks.machine_linux.unclaimed_task_count + data.circleci_runner_unclaimed_tasks.machine_linux.running_task_count,
+  )
+}
@@ -17,4 +17,14 @@ provider "circleci" {
   // specify your self-hosted server's domain here ('https://' not required).
   // This can also be set via CIRCLE_HOSTNAME environment variable,
   hostname = "circleci.com"
+
+  // Defaults to "keyed", serializing writes of env vars and checkout keys
+  // per context and per project.
+  // Set to "global" to serialize all such writes, or "none" to disable.
+  write_lock_granularity = "keyed"
+
+  // Defaults to true.
+  // Set to false to require write-only secrets (e.g., value_wo), of which
+  // only salted hashes are kept in the Terraform state.
+  store_secrets_in_state = true
 }
@@ -0,0 +1,19 @@
+resource "tls_private_key" "deploy" {
+  algorithm = "ED25519"
+}
+
+resource "circleci_additional_ssh_key" "deploy" {
+  project_slug = "github/kelvintaywl/my-project"
+  hostname     = "deploy.example.com"
+  private_key  = tls_private_key.deploy.private_key_openssh
+}
+
+# to be added to the authorized_keys of deploy.example.com;
+# jobs can then use the key via the add_ssh_keys step, with its fingerprint.
+output "deploy_public_key" {
+  value = circleci_additional_ssh_key.deploy.public_key
+}
+
+output "deploy_fingerprint" {
+  value = circleci_additional_ssh_key.deploy.fingerprint
+}
@@ -0,0 +1,24 @@
+locals {
+  // replace with your organization ID
+  org_id = "7f284df8-ac74-42d5-9fad-ab23f731e475"
+}
+
+resource "circleci_context" "example" {
+  name = "example"
+  owner = {
+    id   = local.org_id
+    type = "organization"
+  }
+}
+
+resource "circleci_context_env_vars" "example" {
+  context_id = circleci_context.example.id
+
+  variables = {
+    FOOBAR   = "Lorem Ipsum"
+    FIZZBUZZ = "random1234"
+  }
+
+  // deletes any other env var of the context
+  exclusive = true
+}
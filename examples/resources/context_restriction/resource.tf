@@ -0,0 +1,28 @@
+locals {
+  // replace with your organization ID
+  org_id = "7f284df8-ac74-42d5-9fad-ab23f731e475"
+  // replace with your project ID
+  project_id = "e2e8ae23-57dc-4e95-bc67-633fdb0d8bcc"
+}
+
+resource "circleci_context" "example" {
+  name = "example"
+  owner = {
+    id   = local.org_id
+    type = "organization"
+  }
+}
+
+# only pipelines of this project can use the context
+resource "circleci_context_restriction" "project" {
+  context_id        = circleci_context.example.id
+  restriction_type  = "project"
+  restriction_value = local.project_id
+}
+
+# only pipelines on the main branch can use the context
+resource "circleci_context_restriction" "main_branch" {
+  context_id        = circleci_context.example.id
+  restriction_type  = "expression"
+  restriction_value = "pipeline.git.branch == \"main\""
+}
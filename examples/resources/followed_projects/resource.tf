@@ -0,0 +1,16 @@
+resource "circleci_followed_projects" "services" {
+  organization_slug = "gh/acme"
+  include           = ["-service$", "^website$"]
+  exclude           = ["^legacy-"]
+
+  # unfollow projects once their repository no longer matches
+  unfollow_unmatched = true
+}
+
+# set up every followed project
+resource "circleci_project_settings" "services" {
+  for_each = circleci_followed_projects.services.projects
+
+  project_slug      = each.key
+  autocancel_builds = true
+}
@@ -0,0 +1,6 @@
+# federate with AWS, for every project of the organization
+resource "circleci_org_oidc_claims" "my_org" {
+  org_id   = "1e846a63-ae07-4549-a548-3db2aa4155e8"
+  audience = ["sts.amazonaws.com"]
+  ttl      = "1h"
+}
@@ -0,0 +1,18 @@
+resource "circleci_project" "my_project" {
+  organization_id = "346a7ade-9fae-47ec-b729-da3d5afbe4fc"
+  name            = "foobar"
+}
+
+resource "circleci_pipeline_definition" "build" {
+  project_id  = circleci_project.my_project.id
+  name        = "build"
+  description = "Builds and tests on every push"
+
+  # GitHub repository ID, e.g. from `gh api repos/acme/foobar --jq .id`
+  config_source_provider         = "github_app"
+  config_source_repo_external_id = "123456789"
+  config_source_file_path        = ".circleci/config.yml"
+
+  checkout_source_provider         = "github_app"
+  checkout_source_repo_external_id = "123456789"
+}
@@ -2,6 +2,9 @@
 # ASSUMPTION: the GitHub project has a .circleci/config.yml on its default branch
 resource "circleci_project" "my_project" {
   slug = "github/acme/foobar"
+
+  # stop building the project on CircleCI when removed from Terraform
+  unfollow_on_destroy = true
 }
 
 # add a project env var to this project
@@ -0,0 +1,10 @@
+# create a new CircleCI project, for GitLab or GitHub App organizations
+resource "circleci_project" "my_standalone_project" {
+  organization_id = "346a7ade-9fae-47ec-b729-da3d5afbe4fc"
+  name            = "foobar"
+}
+
+output "standalone_slug" {
+  description = "Project slug (e.g., circleci/<org-id>/<project-id>)"
+  value       = circleci_project.my_standalone_project.slug
+}
@@ -0,0 +1,16 @@
+# API token for the status badge of the project
+resource "circleci_project_api_token" "badge" {
+  project_slug = "github/kelvintaywl/my-project"
+  label        = "status-badge"
+  scope        = "status"
+
+  # rotate the API token every quarter
+  keepers = {
+    quarter = "2024-Q3"
+  }
+}
+
+output "badge_url" {
+  value     = "https://dl.circleci.com/status-badge/img/gh/kelvintaywl/my-project/tree/main.svg?circle-token=${circleci_project_api_token.badge.token}"
+  sensitive = true
+}
@@ -0,0 +1,11 @@
+resource "circleci_project_env_vars" "my_env_vars" {
+  project_slug = "github/acmeorg/foobar"
+
+  variables = {
+    "FOOBAR"   = "0Cme2FmlXk"
+    "FIZZBUZZ" = "Vbt2efixZAkrmTYiirhd"
+  }
+
+  // deletes any other env var of the project
+  exclusive = true
+}
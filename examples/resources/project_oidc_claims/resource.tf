@@ -0,0 +1,9 @@
+# federate with GCP, for this project only
+resource "circleci_project_oidc_claims" "my_project" {
+  org_id     = "1e846a63-ae07-4549-a548-3db2aa4155e8"
+  project_id = "c124cca6-d03e-4733-b84d-32b02347b78c"
+  audience = [
+    "//iam.googleapis.com/projects/123456789/locations/global/workloadIdentityPools/circleci/providers/circleci",
+  ]
+  ttl = "30m"
+}
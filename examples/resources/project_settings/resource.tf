@@ -0,0 +1,8 @@
+resource "circleci_project_settings" "my_project" {
+  project_slug = "github/kelvintaywl/my-project"
+
+  build_fork_prs                = true
+  forks_receive_secret_env_vars = false
+  autocancel_builds             = true
+  setup_workflows               = true
+}
@@ -6,6 +6,8 @@ locals {
 resource "circleci_runner_resource_class" "from_tf" {
   resource_class = "${local.namespace}/${local.resource_class}"
   description    = "Test from Terraform"
+  # also deletes its tokens on destroy
+  force_destroy = true
 }
 
 output "runner_from_tf_id" {
@@ -0,0 +1,17 @@
+resource "circleci_runner_token" "rotating" {
+  resource_class = "kelvintaywl-tf/test"
+  nickname       = "rotating"
+
+  # rotates the token every 30 days, on the next apply after
+  rotate_after = "720h"
+  # rotates the token whenever any of these values change, e.g., after a leak
+  rotation_trigger = {
+    revision = "1"
+  }
+
+  # creates the new token before deleting the old one,
+  # so that runner agents can be redeployed without a gap
+  lifecycle {
+    create_before_destroy = true
+  }
+}
@@ -0,0 +1,12 @@
+resource "circleci_runner_token" "encrypted" {
+  resource_class = "kelvintaywl-tf/test"
+  nickname       = "encrypted"
+  # an armored public key, e.g., via `gpg --armor --export <key-id> > public.asc`,
+  # or a base64-encoded one, e.g., via `gpg --export <key-id> | base64`
+  pgp_key = file("public.asc")
+}
+
+output "encrypted_token" {
+  description = "decrypt with: terraform output -raw encrypted_token | base64 --decode | gpg --decrypt"
+  value       = circleci_runner_token.encrypted.encrypted_token
+}
@@ -0,0 +1,25 @@
+resource "circleci_trigger" "on_push" {
+  project_id             = circleci_pipeline_definition.build.project_id
+  pipeline_definition_id = circleci_pipeline_definition.build.id
+  name                   = "on-push"
+
+  event_source_provider         = "github_app"
+  event_source_repo_external_id = "123456789"
+  event_preset                  = "all-pushes"
+}
+
+# trigger pipelines from any system, by sending events to a webhook
+resource "circleci_trigger" "custom_webhook" {
+  project_id             = circleci_pipeline_definition.build.project_id
+  pipeline_definition_id = circleci_pipeline_definition.build.id
+  name                   = "from-deploy-tool"
+
+  event_source_provider = "webhook"
+  checkout_ref          = "main"
+  config_ref            = "main"
+}
+
+output "webhook_url" {
+  value     = circleci_trigger.custom_webhook.event_source_webhook_url
+  sensitive = true
+}
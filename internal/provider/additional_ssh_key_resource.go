@@ -0,0 +1,363 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &AdditionalSSHKeyResource{}
+var _ resource.ResourceWithModifyPlan = &AdditionalSSHKeyResource{}
+
+func NewAdditionalSSHKeyResource() resource.Resource {
+	return &AdditionalSSHKeyResource{}
+}
+
+type AdditionalSSHKeyResource struct {
+	client *CircleciAPIClient
+}
+
+type AdditionalSSHKeyResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	ProjectSlug    types.String `tfsdk:"project_slug"`
+	Hostname       types.String `tfsdk:"hostname"`
+	PrivateKey     types.String `tfsdk:"private_key"`
+	PrivateKeyWO   types.String `tfsdk:"private_key_wo"`
+	PrivateKeyHash types.String `tfsdk:"private_key_hash"`
+	PublicKey      types.String `tfsdk:"public_key"`
+	Fingerprint    types.String `tfsdk:"fingerprint"`
+}
+
+// sshKeyFingerprint returns the MD5 fingerprint (as shown by CircleCI) and the public key of an unencrypted private key.
+func sshKeyFingerprint(privateKey string) (string, string, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return "", "", err
+	}
+	publicKey := signer.PublicKey()
+	return ssh.FingerprintLegacyMD5(publicKey), strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))), nil
+}
+
+func (r *AdditionalSSHKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_additional_ssh_key"
+}
+
+func (r *AdditionalSSHKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an additional SSH key of a project, for jobs to SSH into other hosts",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Read-only unique identifier: uses fingerprint",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_slug": schema.StringAttribute{
+				MarkdownDescription: "The project-slug for the SSH key",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				MarkdownDescription: "The hostname the SSH key is used for. If empty, the SSH key is used for all hosts.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "The private SSH key, without passphrase (e.g., in PEM or OpenSSH format)",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"private_key_wo":   secretWriteOnlyAttribute("private_key", "The private SSH key, without passphrase (e.g., in PEM or OpenSSH format)."),
+			"private_key_hash": secretHashAttribute("private_key"),
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "The public SSH key, in the authorized_keys format",
+				Computed:            true,
+			},
+			"fingerprint": schema.StringAttribute{
+				MarkdownDescription: "The MD5 fingerprint of the SSH key",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *AdditionalSSHKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AdditionalSSHKeyResource) url(projectSlug string) string {
+	return r.client.apiURL(fmt.Sprintf("/api/v1.1/project/%s/ssh-key", projectSlug))
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *AdditionalSSHKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state AdditionalSSHKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectSlug := state.ProjectSlug.ValueString()
+	fingerprint := state.Fingerprint.ValueString()
+	url := r.client.apiURL(fmt.Sprintf("/api/v1.1/project/%s/settings", projectSlug))
+
+	var settings projectSettings
+	if err := r.client.doJSON(ctx, http.MethodGet, url, nil, &settings); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error reading Project(%s) SSH key %s", projectSlug, fingerprint), fmt.Sprintf("%s", err))
+		return
+	}
+
+	var found *projectSSHKey
+	for i, k := range settings.SSHKeys {
+		if k.Fingerprint == fingerprint && k.Hostname == state.Hostname.ValueString() {
+			found = &settings.SSHKeys[i]
+			break
+		}
+	}
+	if found == nil {
+		tflog.Warn(ctx, fmt.Sprintf("key no longer found: %s", fingerprint))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Id = types.StringValue(found.Fingerprint)
+	if state.PublicKey.IsNull() {
+		// imported
+		state.PublicKey = types.StringValue(strings.TrimSpace(found.PublicKey))
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan computes the fingerprint of the configured private key, and replaces the SSH key when it changes.
+// The hashed private key in state (see private_key_wo) is also kept while it matches the configuration.
+func (r *AdditionalSSHKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to compute when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var privateKey, privateKeyWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("private_key"), &privateKey)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("private_key_wo"), &privateKeyWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !privateKeyWO.IsNull() {
+		privateKey = privateKeyWO
+	}
+	if privateKey.IsUnknown() {
+		// the fingerprint is only known once applied
+		if !req.State.Raw.IsNull() {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("private_key"), path.Root("private_key_hash"))
+		}
+		return
+	}
+
+	fingerprint, publicKey, err := sshKeyFingerprint(privateKey.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("private_key"),
+			"Invalid private SSH key",
+			fmt.Sprintf("Could not parse private SSH key (passphrases are not supported): %s", err.Error()),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("fingerprint"), fingerprint)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("public_key"), publicKey)...)
+
+	if !req.State.Raw.IsNull() {
+		var prior types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("fingerprint"), &prior)...)
+		if prior.ValueString() != fingerprint {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("fingerprint"))
+		} else {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), fingerprint)...)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planSecret(ctx, r.client, req, resp, "private_key", path.Root("public_key"))
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *AdditionalSSHKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan AdditionalSSHKeyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privateKey, diags := configSecret(ctx, req.Config, "private_key")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectSlug := plan.ProjectSlug.ValueString()
+	body := map[string]string{
+		"hostname":    plan.Hostname.ValueString(),
+		"private_key": privateKey,
+	}
+
+	unlock := r.client.lockProject(projectSlug)
+	defer unlock()
+
+	if err := r.client.doJSON(ctx, http.MethodPost, r.url(projectSlug), body, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating project SSH key",
+			fmt.Sprintf("Could not create project SSH key, unexpected error: %s", err.Error()),
+		)
+		return
+	}
+
+	plan.PrivateKeyHash, diags = configSecretHash(ctx, req.Config, "private_key", plan.PrivateKeyHash)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Id = plan.Fingerprint
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only stores the private key, when its fingerprint is unchanged (e.g., after an import).
+func (r *AdditionalSSHKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan AdditionalSSHKeyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.PrivateKeyHash, diags = configSecretHash(ctx, req.Config, "private_key", plan.PrivateKeyHash)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Id = plan.Fingerprint
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *AdditionalSSHKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state AdditionalSSHKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectSlug := state.ProjectSlug.ValueString()
+	fingerprint := state.Fingerprint.ValueString()
+	body := map[string]string{
+		"hostname":    state.Hostname.ValueString(),
+		"fingerprint": fingerprint,
+	}
+
+	unlock := r.client.lockProject(projectSlug)
+	defer unlock()
+
+	err := r.client.doJSON(ctx, http.MethodDelete, r.url(projectSlug), body, nil)
+	if isNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("key no longer found: %s", fingerprint))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting project SSH key",
+			fmt.Sprintf("Could not delete project(%s) SSH key %s, unexpected error: %s", projectSlug, fingerprint, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *AdditionalSSHKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// project slug, hostname, fingerprint
+	// NOTE: the project slug itself contains slashes, so we split on the last two.
+	// The hostname may be empty, for SSH keys used for all hosts.
+	i := strings.LastIndex(req.ID, "/")
+	j := -1
+	if i > 0 {
+		j = strings.LastIndex(req.ID[:i], "/")
+	}
+
+	if j <= 0 || i == len(req.ID)-1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_slug/hostname/fingerprint. Got: %q", req.ID),
+		)
+		return
+	}
+
+	projectSlug := req.ID[:j]
+	hostname := req.ID[j+1 : i]
+	fingerprint := req.ID[i+1:]
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_slug"), projectSlug)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("hostname"), hostname)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fingerprint"), fingerprint)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fingerprint)...)
+}
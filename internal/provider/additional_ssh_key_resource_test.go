@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"golang.org/x/crypto/ssh"
+)
+
+// testPrivateSSHKey returns a new private SSH key in the OpenSSH format, and its public key.
+func testPrivateSSHKey(t *testing.T) (string, ssh.PublicKey) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	publicKey, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return string(pem.EncodeToMemory(block)), publicKey
+}
+
+func TestSSHKeyFingerprint(t *testing.T) {
+	privateKey, publicKey := testPrivateSSHKey(t)
+
+	fingerprint, authorizedKey, err := sshKeyFingerprint(privateKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := ssh.FingerprintLegacyMD5(publicKey); fingerprint != expected {
+		t.Errorf("expected fingerprint %q, got %q", expected, fingerprint)
+	}
+	if !strings.HasPrefix(authorizedKey, "ssh-ed25519 ") || strings.HasSuffix(authorizedKey, "\n") {
+		t.Errorf("unexpected public key %q", authorizedKey)
+	}
+
+	if _, _, err := sshKeyFingerprint("not a key"); err == nil {
+		t.Error("expected an error for an invalid private key")
+	}
+}
+
+func TestAccAdditionalSSHKeyResource(t *testing.T) {
+	privateKey, publicKey := testPrivateSSHKey(t)
+	fingerprint := ssh.FingerprintLegacyMD5(publicKey)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_additional_ssh_key" "k1" {
+	project_slug = "%s"
+	hostname     = "deploy.example.com"
+	private_key  = <<-EOT
+%s
+EOT
+}
+`, projectSlug, strings.TrimSpace(privateKey)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_additional_ssh_key.k1", "id", fingerprint),
+					resource.TestCheckResourceAttr("circleci_additional_ssh_key.k1", "fingerprint", fingerprint),
+					resource.TestCheckResourceAttr("circleci_additional_ssh_key.k1", "hostname", "deploy.example.com"),
+					resource.TestCheckResourceAttrSet("circleci_additional_ssh_key.k1", "public_key"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName: "circleci_additional_ssh_key.k1",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return fmt.Sprintf("%s/deploy.example.com/%s", projectSlug, fingerprint), nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"private_key", "public_key"},
+			},
+		},
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -185,6 +186,9 @@ func (r *CheckoutKeyResource) Create(ctx context.Context, req resource.CreateReq
 
 	param = param.WithBody(&body)
 
+	unlock := r.client.lockProject(projectSlug)
+	defer unlock()
+
 	res, err := r.client.Client.Project.AddProjectCheckoutKey(param, r.client.Auth)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -239,6 +243,9 @@ func (r *CheckoutKeyResource) Delete(ctx context.Context, req resource.DeleteReq
 	param := project.NewDeleteProjectCheckoutKeyParamsWithContext(ctx).WithDefaults()
 	param = param.WithProjectSlug(projectSlug).WithFingerprint(fingerprint)
 
+	unlock := r.client.lockProject(projectSlug)
+	defer unlock()
+
 	_, err := r.client.Client.Project.DeleteProjectCheckoutKey(param, r.client.Auth)
 	if err != nil {
 		errMsg := err.Error()
@@ -259,3 +266,24 @@ func (r *CheckoutKeyResource) Delete(ctx context.Context, req resource.DeleteReq
 		"You would want to delete the public key on the VCS side (e.g., GitHub).",
 	)
 }
+
+func (r *CheckoutKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// project slug, fingerprint
+	// NOTE: the project slug itself contains slashes, so we split on the last one.
+	i := strings.LastIndex(req.ID, "/")
+
+	if i <= 0 || i == len(req.ID)-1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_slug/fingerprint. Got: %q", req.ID),
+		)
+		return
+	}
+
+	projectSlug := req.ID[:i]
+	fingerprint := req.ID[i+1:]
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_slug"), projectSlug)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fingerprint"), fingerprint)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fingerprint)...)
+}
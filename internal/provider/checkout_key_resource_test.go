@@ -33,6 +33,13 @@ func TestAccProjectCheckoutKeyResource(t *testing.T) {
 	//					resource.TestCheckResourceAttrSet("circleci_checkout_key.my_key", "id"),
 	//				),
 	//			},
+	//			// Test Import
+	//			{
+	//				ResourceName:        "circleci_checkout_key.my_key",
+	//				ImportState:         true,
+	//				ImportStateVerify:   true,
+	//				ImportStateIdPrefix: fmt.Sprintf("%s/", projectSlug),
+	//			},
 	//		},
 	//	})
 }
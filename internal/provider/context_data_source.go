@@ -33,6 +33,62 @@ type ContextDataSourceModel struct {
 	Owner     ownerModel   `tfsdk:"owner"`
 	Id        types.String `tfsdk:"id"`
 	CreatedAt types.String `tfsdk:"created_at"`
+
+	Restrictions []contextRestrictionModel `tfsdk:"restrictions"`
+}
+
+type contextRestrictionModel struct {
+	Id               types.String `tfsdk:"id"`
+	RestrictionType  types.String `tfsdk:"restriction_type"`
+	RestrictionValue types.String `tfsdk:"restriction_value"`
+	Name             types.String `tfsdk:"name"`
+}
+
+// contextRestrictionsAttribute describes the restrictions of a context, for data sources.
+func contextRestrictionsAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "The restrictions of the context",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "The unique ID of the restriction",
+					Computed:            true,
+				},
+				"restriction_type": schema.StringAttribute{
+					MarkdownDescription: "The type of restriction: `project`, `expression` or `group`",
+					Computed:            true,
+				},
+				"restriction_value": schema.StringAttribute{
+					MarkdownDescription: "The value of the restriction: a project ID, an expression or a group ID",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "The name of the restricted project or group, or the expression",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+// contextRestrictionModels returns the restrictions of a context, for data sources.
+func (c *CircleciAPIClient) contextRestrictionModels(ctx context.Context, contextID string) ([]contextRestrictionModel, error) {
+	listed, err := c.listContextRestrictions(ctx, contextID)
+	if err != nil {
+		return nil, err
+	}
+
+	restrictions := []contextRestrictionModel{}
+	for _, cr := range listed {
+		restrictions = append(restrictions, contextRestrictionModel{
+			Id:               types.StringValue(cr.ID),
+			RestrictionType:  types.StringValue(cr.RestrictionType),
+			RestrictionValue: types.StringValue(cr.RestrictionValue),
+			Name:             types.StringValue(cr.Name),
+		})
+	}
+	return restrictions, nil
 }
 
 func (d *ContextDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -57,6 +113,7 @@ func (d *ContextDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				MarkdownDescription: "The date and time the context was created",
 				Computed:            true,
 			},
+			"restrictions": contextRestrictionsAttribute(),
 			"owner": schema.SingleNestedAttribute{
 				MarkdownDescription: "The owner of the context",
 				Required:            true,
@@ -132,6 +189,12 @@ func (d *ContextDataSource) Read(ctx context.Context, req datasource.ReadRequest
 				createdAt := c.CreatedAt.String()
 				data.CreatedAt = types.StringValue(createdAt)
 
+				data.Restrictions, err = d.client.contextRestrictionModels(ctx, id)
+				if err != nil {
+					resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
+					return
+				}
+
 				// Save data into Terraform state
 				diags := resp.State.Set(ctx, &data)
 				resp.Diagnostics.Append(diags...)
@@ -25,6 +25,7 @@ data "circleci_context" "test" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.circleci_context.test", "id", contextId),
 					resource.TestCheckResourceAttrSet("data.circleci_context.test", "created_at"),
+					resource.TestCheckResourceAttrSet("data.circleci_context.test", "restrictions.#"),
 				),
 			},
 			// Read testing for standalone
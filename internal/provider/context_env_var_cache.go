@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kelvintaywl/circleci-go-sdk/client/contexts"
+	"github.com/kelvintaywl/circleci-go-sdk/models"
+)
+
+// contextEnvVarCache caches the env var listing of each context, keyed by context ID.
+// Concurrent lookups for the same context share a single in-flight listing.
+// It lives within the API client, which is configured for each Terraform operation.
+type contextEnvVarCache struct {
+	mu      sync.Mutex
+	entries map[string]*contextEnvVarCacheEntry
+}
+
+type contextEnvVarCacheEntry struct {
+	// closed once the listing is done
+	done    chan struct{}
+	envVars []*models.ContextEnvVarInfo
+	err     error
+}
+
+func newContextEnvVarCache() *contextEnvVarCache {
+	return &contextEnvVarCache{
+		entries: map[string]*contextEnvVarCacheEntry{},
+	}
+}
+
+// get returns the cached listing for the context, calling list only if there is none yet.
+// The listing is shared, so it runs on a context no single caller can cancel:
+// a cancelled caller stops waiting, while the others still get the listing.
+// Failed listings are not cached.
+func (c *contextEnvVarCache) get(ctx context.Context, contextId string, list func(context.Context) ([]*models.ContextEnvVarInfo, error)) ([]*models.ContextEnvVarInfo, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[contextId]
+	if ok {
+		tflog.Debug(ctx, "Using cached env vars for context "+contextId)
+	} else {
+		entry = &contextEnvVarCacheEntry{done: make(chan struct{})}
+		c.entries[contextId] = entry
+		go c.fill(context.WithoutCancel(ctx), contextId, entry, list)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-entry.done:
+		return entry.envVars, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fill lists the env vars of the context into the entry, dropping the entry if the listing failed.
+func (c *contextEnvVarCache) fill(ctx context.Context, contextId string, entry *contextEnvVarCacheEntry, list func(context.Context) ([]*models.ContextEnvVarInfo, error)) {
+	entry.envVars, entry.err = list(ctx)
+	if entry.err != nil {
+		c.mu.Lock()
+		if c.entries[contextId] == entry {
+			delete(c.entries, contextId)
+		}
+		c.mu.Unlock()
+	}
+	close(entry.done)
+}
+
+// invalidate drops the cached listing for the context, e.g., after a write.
+func (c *contextEnvVarCache) invalidate(contextId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, contextId)
+}
+
+// listContextEnvVars pages through all the environment variables of a context.
+// The listing is shared with other lookups for the same context, until invalidated.
+func listContextEnvVars(ctx context.Context, client *CircleciAPIClient, contextId string) ([]*models.ContextEnvVarInfo, error) {
+	list := func(ctx context.Context) ([]*models.ContextEnvVarInfo, error) {
+		var envVars []*models.ContextEnvVarInfo
+		nextToken := ""
+
+		for {
+			param := contexts.NewListContextEnvVarsParamsWithContext(ctx).WithDefaults()
+			param = param.WithID(strfmt.UUID(contextId)).WithPageToken(&nextToken)
+
+			res, err := client.Client.Contexts.ListContextEnvVars(param, client.Auth)
+			if err != nil {
+				return nil, err
+			}
+
+			info := res.GetPayload()
+			envVars = append(envVars, info.Items...)
+
+			nextToken = info.NextPageToken
+			if nextToken == "" {
+				return envVars, nil
+			}
+		}
+	}
+
+	if client.contextEnvVars == nil {
+		return list(ctx)
+	}
+	return client.contextEnvVars.get(ctx, contextId, list)
+}
+
+// invalidateContextEnvVars drops the cached listing for the context, after a write.
+func invalidateContextEnvVars(client *CircleciAPIClient, contextId string) {
+	if client.contextEnvVars == nil {
+		return
+	}
+	client.contextEnvVars.invalidate(contextId)
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kelvintaywl/circleci-go-sdk/models"
+)
+
+func TestContextEnvVarCache(t *testing.T) {
+	ctx := context.Background()
+	cache := newContextEnvVarCache()
+
+	var calls int32
+	release := make(chan struct{})
+	list := func(context.Context) ([]*models.ContextEnvVarInfo, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return []*models.ContextEnvVarInfo{{Variable: "FOOBAR"}}, nil
+	}
+
+	// concurrent lookups share the same in-flight listing
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			envVars, err := cache.get(ctx, contextId, list)
+			if err != nil || len(envVars) != 1 || envVars[0].Variable != "FOOBAR" {
+				t.Errorf("unexpected listing: %v, %v", envVars, err)
+			}
+		}()
+	}
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("expected 1 listing, got %d", calls)
+	}
+
+	// other contexts are listed separately
+	if _, err := cache.get(ctx, standaloneContextId, list); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 listings, got %d", calls)
+	}
+
+	// invalidated contexts are listed again
+	cache.invalidate(contextId)
+	if _, err := cache.get(ctx, contextId, list); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 listings, got %d", calls)
+	}
+}
+
+func TestContextEnvVarCacheError(t *testing.T) {
+	ctx := context.Background()
+	cache := newContextEnvVarCache()
+
+	failing := func(context.Context) ([]*models.ContextEnvVarInfo, error) {
+		return nil, errors.New("boom")
+	}
+	if _, err := cache.get(ctx, contextId, failing); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// failed listings are not cached
+	envVars, err := cache.get(ctx, contextId, func(context.Context) ([]*models.ContextEnvVarInfo, error) {
+		return []*models.ContextEnvVarInfo{}, nil
+	})
+	if err != nil || envVars == nil {
+		t.Fatalf("expected listing to be retried, got %v, %v", envVars, err)
+	}
+}
+
+func TestContextEnvVarCacheCancel(t *testing.T) {
+	cache := newContextEnvVarCache()
+
+	release := make(chan struct{})
+	list := func(ctx context.Context) ([]*models.ContextEnvVarInfo, error) {
+		<-release
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return []*models.ContextEnvVarInfo{{Variable: "FOOBAR"}}, nil
+	}
+
+	// the first caller starts the listing, then is cancelled
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := cache.get(first, contextId, list)
+		firstErr <- err
+	}()
+
+	// another caller waits for the same listing
+	other := make(chan []*models.ContextEnvVarInfo)
+	go func() {
+		envVars, _ := cache.get(context.Background(), contextId, list)
+		other <- envVars
+	}()
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancelled caller to stop waiting, got %v", err)
+	}
+
+	// the shared listing is not cancelled with the first caller
+	close(release)
+	if envVars := <-other; len(envVars) != 1 || envVars[0].Variable != "FOOBAR" {
+		t.Fatalf("unexpected listing: %v", envVars)
+	}
+}
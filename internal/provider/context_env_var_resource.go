@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-openapi/strfmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -21,6 +22,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &ContextEnvVarResource{}
+var _ resource.ResourceWithModifyPlan = &ContextEnvVarResource{}
 
 func NewContextEnvVarResource() resource.Resource {
 	return &ContextEnvVarResource{}
@@ -34,6 +36,8 @@ type ContextEnvVarResourceModel struct {
 	ContextId types.String `tfsdk:"context_id"`
 	Name      types.String `tfsdk:"name"`
 	Value     types.String `tfsdk:"value"`
+	ValueWO   types.String `tfsdk:"value_wo"`
+	ValueHash types.String `tfsdk:"value_hash"`
 	Id        types.String `tfsdk:"id"`
 	CreatedAt types.String `tfsdk:"created_at"`
 	UpdatedAt types.String `tfsdk:"updated_at"`
@@ -72,10 +76,12 @@ func (r *ContextEnvVarResource) Schema(_ context.Context, _ resource.SchemaReque
 				Required:            true,
 			},
 			"value": schema.StringAttribute{
-				MarkdownDescription: "The value of the context environment variable",
-				Required:            true,
+				MarkdownDescription: "The value of the context environment variable. This is not set when imported, and is written on the next apply.",
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"value_wo":   secretWriteOnlyAttribute("value", "The value of the context environment variable."),
+			"value_hash": secretHashAttribute("value"),
 			"context_id": schema.StringAttribute{
 				MarkdownDescription: "ID of the context",
 				Required:            true,
@@ -117,43 +123,39 @@ func (r *ContextEnvVarResource) Read(ctx context.Context, req resource.ReadReque
 
 	name := state.Name.ValueString()
 	contextId := state.ContextId.ValueString()
-	nextToken := ""
-
-	for {
-		param := contexts.NewListContextEnvVarsParamsWithContext(ctx).WithDefaults()
-		param = param.WithID(strfmt.UUID(contextId)).WithPageToken(&nextToken)
 
-		res, err := r.client.Client.Contexts.ListContextEnvVars(param, r.client.Auth)
-		if err != nil {
-			resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
-			return
-		}
+	// the listing is shared with the other env vars of the same context
+	envVars, err := listContextEnvVars(ctx, r.client, contextId)
+	if err != nil {
+		resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
+		return
+	}
 
-		info := res.GetPayload()
-		nextToken = info.NextPageToken
-		for _, ev := range info.Items {
-			if ev.Variable == name {
-				id := fmt.Sprintf("%s/%s", contextId, name)
-				state.Id = types.StringValue(id)
-				createdAt := ev.CreatedAt.String()
-				state.CreatedAt = types.StringValue(createdAt)
-				updatedAt := ev.UpdatedAt.String()
-				state.UpdatedAt = types.StringValue(updatedAt)
-
-				// Save data into Terraform state
-				diags := resp.State.Set(ctx, &state)
-				resp.Diagnostics.Append(diags...)
-				if resp.Diagnostics.HasError() {
-					return
-				}
+	for _, ev := range envVars {
+		if ev.Variable == name {
+			id := fmt.Sprintf("%s/%s", contextId, name)
+			state.Id = types.StringValue(id)
+			createdAt := ev.CreatedAt.String()
+			state.CreatedAt = types.StringValue(createdAt)
+			updatedAt := ev.UpdatedAt.String()
+			state.UpdatedAt = types.StringValue(updatedAt)
+
+			// Save data into Terraform state
+			diags := resp.State.Set(ctx, &state)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
 				return
 			}
-		}
-		if nextToken == "" && state.Id.ValueString() == "" {
-			resp.Diagnostics.AddError(fmt.Sprintf("Did not find context env var with name %s", name), fmt.Sprintf("%s", err))
 			return
 		}
 	}
+
+	resp.Diagnostics.AddError(fmt.Sprintf("Did not find context env var with name %s", name), fmt.Sprintf("context %s", contextId))
+}
+
+// ModifyPlan keeps the hashed value (see value_wo) in the plan while it matches the configuration.
+func (r *ContextEnvVarResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planSecret(ctx, r.client, req, resp, "value", path.Root("updated_at"))
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -171,14 +173,22 @@ func (r *ContextEnvVarResource) Create(ctx context.Context, req resource.CreateR
 	param := contexts.NewUpdateContextEnvVarParamsWithContext(ctx).WithDefaults()
 	param = param.WithID(strfmt.UUID(contextId)).WithName(name)
 
-	value := plan.Value.ValueString()
+	value, diags := configSecret(ctx, req.Config, "value")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	body := models.ContextEnvVarPayload{
 		Value: &value,
 	}
 
 	param = param.WithBody(&body)
 
+	unlock := r.client.lockContext(contextId)
+	defer unlock()
+
 	res, err := r.client.Client.Contexts.UpdateContextEnvVar(param, r.client.Auth)
+	invalidateContextEnvVars(r.client, contextId)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating context env var",
@@ -189,6 +199,12 @@ func (r *ContextEnvVarResource) Create(ctx context.Context, req resource.CreateR
 
 	ev := res.GetPayload()
 
+	plan.ValueHash, diags = configSecretHash(ctx, req.Config, "value", plan.ValueHash)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	plan.Id = types.StringValue(fmt.Sprintf("%s/%s", contextId, name))
 	plan.CreatedAt = types.StringValue(ev.CreatedAt.String())
 	plan.UpdatedAt = types.StringValue(ev.UpdatedAt.String())
@@ -213,14 +229,22 @@ func (r *ContextEnvVarResource) Update(ctx context.Context, req resource.UpdateR
 	param := contexts.NewUpdateContextEnvVarParamsWithContext(ctx).WithDefaults()
 	param = param.WithID(strfmt.UUID(contextId)).WithName(name)
 
-	value := plan.Value.ValueString()
+	value, diags := configSecret(ctx, req.Config, "value")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	body := models.ContextEnvVarPayload{
 		Value: &value,
 	}
 
 	param = param.WithBody(&body)
 
+	unlock := r.client.lockContext(contextId)
+	defer unlock()
+
 	res, err := r.client.Client.Contexts.UpdateContextEnvVar(param, r.client.Auth)
+	invalidateContextEnvVars(r.client, contextId)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating context env var",
@@ -231,6 +255,12 @@ func (r *ContextEnvVarResource) Update(ctx context.Context, req resource.UpdateR
 
 	ev := res.GetPayload()
 
+	plan.ValueHash, diags = configSecretHash(ctx, req.Config, "value", plan.ValueHash)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	plan.Id = types.StringValue(fmt.Sprintf("%s/%s", contextId, name))
 	plan.CreatedAt = types.StringValue(ev.CreatedAt.String())
 	plan.UpdatedAt = types.StringValue(ev.UpdatedAt.String())
@@ -257,7 +287,11 @@ func (r *ContextEnvVarResource) Delete(ctx context.Context, req resource.DeleteR
 	param := contexts.NewDeleteContextEnvVarParamsWithContext(ctx).WithDefaults()
 	param = param.WithID(strfmt.UUID(contextId)).WithName(name)
 
+	unlock := r.client.lockContext(contextId)
+	defer unlock()
+
 	_, err := r.client.Client.Contexts.DeleteContextEnvVar(param, r.client.Auth)
+	invalidateContextEnvVars(r.client, contextId)
 	if err != nil {
 		errMsg := err.Error()
 		if strings.Contains(errMsg, "not found") {
@@ -271,3 +305,25 @@ func (r *ContextEnvVarResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 }
+
+func (r *ContextEnvVarResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// context ID, name
+	idParts := strings.Split(req.ID, "/")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: context_id/name. Got: %q", req.ID),
+		)
+		return
+	}
+
+	contextId := idParts[0]
+	name := idParts[1]
+
+	// CircleCI does not return the value, so it is left unset.
+	// The configured value is then written on the next apply.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("context_id"), contextId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -76,6 +76,13 @@ resource "circleci_context_env_var" "env2" {
 					resource.TestCheckResourceAttrSet("circleci_context_env_var.env2", "updated_at"),
 				),
 			},
+			// Test Import
+			{
+				ResourceName:            "circleci_context_env_var.env2",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"value"},
+			},
 			// Create and Read testing for standalone
 			{
 				Config: providerConfig + fmt.Sprintf(`
@@ -0,0 +1,365 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kelvintaywl/circleci-go-sdk/client/contexts"
+	"github.com/kelvintaywl/circleci-go-sdk/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ContextEnvVarsResource{}
+var _ resource.ResourceWithModifyPlan = &ContextEnvVarsResource{}
+
+func NewContextEnvVarsResource() resource.Resource {
+	return &ContextEnvVarsResource{}
+}
+
+type ContextEnvVarsResource struct {
+	client *CircleciAPIClient
+}
+
+type ContextEnvVarsResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	ContextId      types.String `tfsdk:"context_id"`
+	Variables      types.Map    `tfsdk:"variables"`
+	VariablesWO    types.Map    `tfsdk:"variables_wo"`
+	VariablesHash  types.Map    `tfsdk:"variables_hash"`
+	Exclusive      types.Bool   `tfsdk:"exclusive"`
+	UpdatedAt      types.Map    `tfsdk:"updated_at"`
+	UnmanagedNames types.Set    `tfsdk:"unmanaged_names"`
+}
+
+func (r *ContextEnvVarsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_context_env_vars"
+}
+
+func (r *ContextEnvVarsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a set of context environment variables",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Read-only unique identifier: uses context_id",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"context_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the context",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"variables": schema.MapAttribute{
+				MarkdownDescription: "The context environment variables, as a map of name to value",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"variables_wo":   envVarsWriteOnlyAttribute("The context environment variables, as a map of name to value."),
+			"variables_hash": envVarsHashAttribute(),
+			"exclusive": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete any environment variable of the context not found in `variables` (default: false)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"updated_at": schema.MapAttribute{
+				MarkdownDescription: "The date and time each context environment variable was last updated, as a map of name to date and time",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"unmanaged_names": schema.SetAttribute{
+				MarkdownDescription: "Names of the context environment variables not found in `variables`. These are deleted when `exclusive` is true",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *ContextEnvVarsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// envVarSet returns the env vars of the context, managed as a whole.
+func (r *ContextEnvVarsResource) envVarSet(contextId string) *envVarSet {
+	return &envVarSet{
+		owner: fmt.Sprintf("Context(%s)", contextId),
+		list: func(ctx context.Context) ([]string, error) {
+			envVars, err := listContextEnvVars(ctx, r.client, contextId)
+			if err != nil {
+				return nil, err
+			}
+			names := []string{}
+			for _, ev := range envVars {
+				names = append(names, ev.Variable)
+			}
+			return names, nil
+		},
+		upsert: func(ctx context.Context, name, value string) (string, error) {
+			param := contexts.NewUpdateContextEnvVarParamsWithContext(ctx).WithDefaults()
+			param = param.WithID(strfmt.UUID(contextId)).WithName(name)
+
+			body := models.ContextEnvVarPayload{
+				Value: &value,
+			}
+
+			param = param.WithBody(&body)
+
+			unlock := r.client.lockContext(contextId)
+			defer unlock()
+
+			res, err := r.client.Client.Contexts.UpdateContextEnvVar(param, r.client.Auth)
+			invalidateContextEnvVars(r.client, contextId)
+			if err != nil {
+				return "", err
+			}
+			return res.GetPayload().UpdatedAt.String(), nil
+		},
+		delete: func(ctx context.Context, name string) error {
+			param := contexts.NewDeleteContextEnvVarParamsWithContext(ctx).WithDefaults()
+			param = param.WithID(strfmt.UUID(contextId)).WithName(name)
+
+			unlock := r.client.lockContext(contextId)
+			defer unlock()
+
+			_, err := r.client.Client.Contexts.DeleteContextEnvVar(param, r.client.Auth)
+			invalidateContextEnvVars(r.client, contextId)
+			return err
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ContextEnvVarsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state ContextEnvVarsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contextId := state.ContextId.ValueString()
+	envVars, err := listContextEnvVars(ctx, r.client, contextId)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error reading context %s env vars", contextId), fmt.Sprintf("%s", err))
+		return
+	}
+
+	prior, diags := envVarsFromState(ctx, state.Variables, state.VariablesHash)
+	resp.Diagnostics.Append(diags...)
+	updatedAt := map[string]string{}
+	resp.Diagnostics.Append(state.UpdatedAt.ElementsAs(ctx, &updatedAt, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := map[string]string{}
+	names := []string{}
+	for _, ev := range envVars {
+		found[ev.Variable] = ev.UpdatedAt.String()
+		names = append(names, ev.Variable)
+	}
+	unmanaged := unmanagedNames(names, prior.names())
+
+	// CircleCI does not return the values.
+	// Hence, we drop the variables that no longer exist or were updated elsewhere, so they are written again.
+	for name := range prior.names() {
+		remoteUpdatedAt, ok := found[name]
+		switch {
+		case !ok:
+			tflog.Warn(ctx, fmt.Sprintf("Context env var no longer found: %s/%s", contextId, name))
+		case remoteUpdatedAt != updatedAt[name]:
+			tflog.Warn(ctx, fmt.Sprintf("Context env var was updated outside of Terraform: %s/%s", contextId, name))
+		default:
+			continue
+		}
+		prior.drop(name)
+		delete(updatedAt, name)
+	}
+
+	state.Id = types.StringValue(contextId)
+	state.Variables, state.VariablesHash, diags = prior.toState(ctx)
+	resp.Diagnostics.Append(diags...)
+	state.UpdatedAt, diags = types.MapValueFrom(ctx, types.StringType, updatedAt)
+	resp.Diagnostics.Append(diags...)
+	state.UnmanagedNames, diags = types.SetValueFrom(ctx, types.StringType, unmanaged)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan keeps the hashed values (see variables_wo) in the plan while they match the configuration,
+// and reports the unmanaged env vars, which are either kept or deleted based on exclusive.
+func (r *ContextEnvVarsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var contextId types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("context_id"), &contextId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planEnvVars(ctx, r.client, req, resp, fmt.Sprintf("Context(%s)", contextId.ValueString()), path.Root("updated_at"))
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ContextEnvVarsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan ContextEnvVarsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, writeOnly, diags := configEnvVars(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contextId := plan.ContextId.ValueString()
+	updatedAt, unmanaged, err := r.envVarSet(contextId).sync(ctx, planned, envVarsInState{}, map[string]string{}, plan.Exclusive.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating context env vars",
+			fmt.Sprintf("Could not create context %s env vars, unexpected error: %s", contextId, err.Error()),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(contextId)
+	plan.VariablesHash, diags = appliedEnvVarHashes(ctx, planned, writeOnly, envVarsInState{}, plan.VariablesHash)
+	resp.Diagnostics.Append(diags...)
+	plan.UpdatedAt, diags = types.MapValueFrom(ctx, types.StringType, updatedAt)
+	resp.Diagnostics.Append(diags...)
+	plan.UnmanagedNames, diags = appliedUnmanagedNames(ctx, plan.UnmanagedNames, unmanaged)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ContextEnvVarsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state ContextEnvVarsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, writeOnly, diags := configEnvVars(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	prior, diags := envVarsFromState(ctx, state.Variables, state.VariablesHash)
+	resp.Diagnostics.Append(diags...)
+	priorUpdatedAt := map[string]string{}
+	resp.Diagnostics.Append(state.UpdatedAt.ElementsAs(ctx, &priorUpdatedAt, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contextId := plan.ContextId.ValueString()
+	updatedAt, unmanaged, err := r.envVarSet(contextId).sync(ctx, planned, prior, priorUpdatedAt, plan.Exclusive.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating context env vars",
+			fmt.Sprintf("Could not update context %s env vars, unexpected error: %s", contextId, err.Error()),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(contextId)
+	plan.VariablesHash, diags = appliedEnvVarHashes(ctx, planned, writeOnly, prior, plan.VariablesHash)
+	resp.Diagnostics.Append(diags...)
+	plan.UpdatedAt, diags = types.MapValueFrom(ctx, types.StringType, updatedAt)
+	resp.Diagnostics.Append(diags...)
+	plan.UnmanagedNames, diags = appliedUnmanagedNames(ctx, plan.UnmanagedNames, unmanaged)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ContextEnvVarsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state ContextEnvVarsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := envVarsFromState(ctx, state.Variables, state.VariablesHash)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the managed env vars are deleted
+	contextId := state.ContextId.ValueString()
+	if err := r.envVarSet(contextId).deleteAll(ctx, prior); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting context env vars",
+			fmt.Sprintf("Could not delete context %s env vars, unexpected error: %s", contextId, err.Error()),
+		)
+		return
+	}
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccContextEnvVarsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "circleci_context" "test" {
+	name = "%s"
+	owner = {
+		id   = "%s"
+		type = "organization"
+	}
+}
+
+resource "circleci_context_env_vars" "envs" {
+	context_id = data.circleci_context.test.id
+	variables = {
+		LOREM = "random1234"
+		IPSUM = "Lorem Ipsum"
+	}
+}
+`, contextName, orgId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_context_env_vars.envs", "context_id", contextId),
+					resource.TestCheckResourceAttr("circleci_context_env_vars.envs", "id", contextId),
+					resource.TestCheckResourceAttr("circleci_context_env_vars.envs", "exclusive", "false"),
+					resource.TestCheckResourceAttr("circleci_context_env_vars.envs", "variables.%", "2"),
+					resource.TestCheckResourceAttr("circleci_context_env_vars.envs", "variables.LOREM", "random1234"),
+					resource.TestCheckResourceAttr("circleci_context_env_vars.envs", "variables.IPSUM", "Lorem Ipsum"),
+					resource.TestCheckResourceAttrSet("circleci_context_env_vars.envs", "updated_at.LOREM"),
+					resource.TestCheckResourceAttrSet("circleci_context_env_vars.envs", "updated_at.IPSUM"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "circleci_context" "test" {
+	name = "%s"
+	owner = {
+		id   = "%s"
+		type = "organization"
+	}
+}
+
+resource "circleci_context_env_vars" "envs" {
+	context_id = data.circleci_context.test.id
+	variables = {
+		LOREM = "changed"
+		DOLOR = "sit amet"
+	}
+}
+`, contextName, orgId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_context_env_vars.envs", "variables.%", "2"),
+					resource.TestCheckResourceAttr("circleci_context_env_vars.envs", "variables.LOREM", "changed"),
+					resource.TestCheckResourceAttr("circleci_context_env_vars.envs", "variables.DOLOR", "sit amet"),
+					resource.TestCheckNoResourceAttr("circleci_context_env_vars.envs", "variables.IPSUM"),
+					resource.TestCheckResourceAttr("circleci_context_env_vars.envs", "updated_at.%", "2"),
+				),
+			},
+			// Create and Read testing for standalone, as exclusive
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "circleci_context" "standalone" {
+	name = "%s"
+	owner = {
+		id   = "%s"
+		type = "organization"
+	}
+}
+
+resource "circleci_context_env_vars" "standalone" {
+	context_id = data.circleci_context.standalone.id
+	variables = {
+		IPSUM = "standalone123"
+	}
+	exclusive = true
+}
+`, standaloneContextName, standaloneOrgId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_context_env_vars.standalone", "context_id", standaloneContextId),
+					resource.TestCheckResourceAttr("circleci_context_env_vars.standalone", "exclusive", "true"),
+					resource.TestCheckResourceAttr("circleci_context_env_vars.standalone", "variables.IPSUM", "standalone123"),
+					resource.TestCheckResourceAttr("circleci_context_env_vars.standalone", "unmanaged_names.#", "0"),
+				),
+			},
+		},
+	})
+}
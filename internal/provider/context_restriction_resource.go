@@ -0,0 +1,298 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ContextRestrictionResource{}
+
+func NewContextRestrictionResource() resource.Resource {
+	return &ContextRestrictionResource{}
+}
+
+type ContextRestrictionResource struct {
+	client *CircleciAPIClient
+}
+
+type ContextRestrictionResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	ContextId        types.String `tfsdk:"context_id"`
+	RestrictionType  types.String `tfsdk:"restriction_type"`
+	RestrictionValue types.String `tfsdk:"restriction_value"`
+	Name             types.String `tfsdk:"name"`
+}
+
+var vRestrictionTypes = []string{
+	"project",
+	"expression",
+	"group",
+}
+
+// contextRestriction is a restriction of a context, in the v2 API.
+type contextRestriction struct {
+	ID               string `json:"id,omitempty"`
+	ContextID        string `json:"context_id,omitempty"`
+	Name             string `json:"name,omitempty"`
+	RestrictionType  string `json:"restriction_type"`
+	RestrictionValue string `json:"restriction_value"`
+}
+
+func (c *CircleciAPIClient) contextRestrictionsURL(contextID string) string {
+	return c.apiURL(fmt.Sprintf("/api/v2/context/%s/restrictions", contextID))
+}
+
+// listContextRestrictions lists every restriction of a context.
+func (c *CircleciAPIClient) listContextRestrictions(ctx context.Context, contextID string) ([]contextRestriction, error) {
+	var restrictions []contextRestriction
+	nextToken := ""
+	for {
+		u := c.contextRestrictionsURL(contextID)
+		if nextToken != "" {
+			u = fmt.Sprintf("%s?page-token=%s", u, url.QueryEscape(nextToken))
+		}
+
+		var page struct {
+			Items         []contextRestriction `json:"items"`
+			NextPageToken string               `json:"next_page_token"`
+		}
+		if err := c.doJSON(ctx, http.MethodGet, u, nil, &page); err != nil {
+			return nil, err
+		}
+		restrictions = append(restrictions, page.Items...)
+
+		nextToken = page.NextPageToken
+		if nextToken == "" {
+			return restrictions, nil
+		}
+	}
+}
+
+func (r *ContextRestrictionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_context_restriction"
+}
+
+func (r *ContextRestrictionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a restriction of a context",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Read-only unique identifier",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"context_id": schema.StringAttribute{
+				MarkdownDescription: "The unique ID of the context",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"restriction_type": schema.StringAttribute{
+				MarkdownDescription: "The type of restriction. This may be either `project`, `expression` or `group`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(vRestrictionTypes...),
+				},
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"restriction_value": schema.StringAttribute{
+				MarkdownDescription: "The value of the restriction: a project ID, an expression (e.g., `pipeline.git.branch == \"main\"`) or a group ID",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the restricted project or group, or the expression",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *ContextRestrictionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ContextRestrictionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state ContextRestrictionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.Id.ValueString()
+	contextID := state.ContextId.ValueString()
+
+	restrictions, err := r.client.listContextRestrictions(ctx, contextID)
+	if isNotFound(err) {
+		// e.g., the context was deleted
+		tflog.Warn(ctx, fmt.Sprintf("Context(%s) no longer found; removing restriction %s from state.", contextID, id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error reading Context(%s) restriction %s", contextID, id), fmt.Sprintf("%s", err))
+		return
+	}
+
+	var found *contextRestriction
+	for i, cr := range restrictions {
+		if cr.ID == id {
+			found = &restrictions[i]
+			break
+		}
+	}
+	if found == nil {
+		tflog.Warn(ctx, fmt.Sprintf("restriction no longer found: %s", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.RestrictionType = types.StringValue(found.RestrictionType)
+	state.RestrictionValue = types.StringValue(found.RestrictionValue)
+	state.Name = types.StringValue(found.Name)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ContextRestrictionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan ContextRestrictionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contextID := plan.ContextId.ValueString()
+	body := contextRestriction{
+		RestrictionType:  plan.RestrictionType.ValueString(),
+		RestrictionValue: plan.RestrictionValue.ValueString(),
+	}
+
+	unlock := r.client.lockContext(contextID)
+	defer unlock()
+
+	var cr contextRestriction
+	if err := r.client.doJSON(ctx, http.MethodPost, r.client.contextRestrictionsURL(contextID), body, &cr); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating context restriction",
+			fmt.Sprintf("Could not create context restriction, unexpected error: %s", err.Error()),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(cr.ID)
+	plan.Name = types.StringValue(cr.Name)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ContextRestrictionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// not implemented; requires a replacement
+}
+
+func (r *ContextRestrictionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state ContextRestrictionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.Id.ValueString()
+	contextID := state.ContextId.ValueString()
+	u := fmt.Sprintf("%s/%s", r.client.contextRestrictionsURL(contextID), id)
+
+	unlock := r.client.lockContext(contextID)
+	defer unlock()
+
+	err := r.client.doJSON(ctx, http.MethodDelete, u, nil, nil)
+	if isNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("restriction no longer found: %s", id))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting context restriction",
+			fmt.Sprintf("Could not delete context(%s) restriction %s, unexpected error: %s", contextID, id, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *ContextRestrictionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	contextID, id, ok := strings.Cut(req.ID, "/")
+	if !ok || contextID == "" || id == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: context_id/restriction_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("context_id"), contextID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
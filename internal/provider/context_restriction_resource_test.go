@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccContextRestrictionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_context_restriction" "project" {
+	context_id        = "%s"
+	restriction_type  = "project"
+	restriction_value = "%s"
+}
+
+resource "circleci_context_restriction" "expression" {
+	context_id        = "%s"
+	restriction_type  = "expression"
+	restriction_value = "pipeline.git.branch == \"main\""
+}
+`, contextId, projectId, contextId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("circleci_context_restriction.project", "id"),
+					resource.TestCheckResourceAttr("circleci_context_restriction.project", "context_id", contextId),
+					resource.TestCheckResourceAttr("circleci_context_restriction.project", "restriction_type", "project"),
+					resource.TestCheckResourceAttr("circleci_context_restriction.project", "restriction_value", projectId),
+					resource.TestCheckResourceAttrSet("circleci_context_restriction.project", "name"),
+
+					resource.TestCheckResourceAttrSet("circleci_context_restriction.expression", "id"),
+					resource.TestCheckResourceAttr("circleci_context_restriction.expression", "restriction_type", "expression"),
+					resource.TestCheckResourceAttr("circleci_context_restriction.expression", "restriction_value", "pipeline.git.branch == \"main\""),
+				),
+			},
+			// Test Import
+			{
+				ResourceName:      "circleci_context_restriction.expression",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["circleci_context_restriction.expression"]
+					if !ok {
+						return "", fmt.Errorf("resource not found in state")
+					}
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["context_id"], rs.Primary.ID), nil
+				},
+			},
+			// Update (replace) and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_context_restriction" "project" {
+	context_id        = "%s"
+	restriction_type  = "project"
+	restriction_value = "%s"
+}
+
+resource "circleci_context_restriction" "expression" {
+	context_id        = "%s"
+	restriction_type  = "expression"
+	restriction_value = "pipeline.git.branch != \"dev\""
+}
+`, contextId, projectId, contextId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_context_restriction.expression", "restriction_value", "pipeline.git.branch != \"dev\""),
+				),
+			},
+		},
+	})
+}
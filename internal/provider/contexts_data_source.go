@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kelvintaywl/circleci-go-sdk/client/contexts"
+	"github.com/kelvintaywl/circleci-go-sdk/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ContextsDataSource{}
+
+func NewContextsDataSource() datasource.DataSource {
+	return &ContextsDataSource{}
+}
+
+type ContextsDataSource struct {
+	client *CircleciAPIClient
+}
+
+// ContextsDataSourceModel describes the data source data model.
+type ContextsDataSourceModel struct {
+	Id                  types.String        `tfsdk:"id"`
+	Owner               ownerModel          `tfsdk:"owner"`
+	NameRegex           types.String        `tfsdk:"name_regex"`
+	IncludeEnvVars      types.Bool          `tfsdk:"include_env_vars"`
+	IncludeRestrictions types.Bool          `tfsdk:"include_restrictions"`
+	Contexts            []contextsItemModel `tfsdk:"contexts"`
+}
+
+type contextsItemModel struct {
+	Id           types.String              `tfsdk:"id"`
+	Name         types.String              `tfsdk:"name"`
+	CreatedAt    types.String              `tfsdk:"created_at"`
+	EnvVarNames  []types.String            `tfsdk:"env_var_names"`
+	Restrictions []contextRestrictionModel `tfsdk:"restrictions"`
+}
+
+// filterContexts returns the contexts whose name matches the regular expression, if any.
+func filterContexts(items []*models.ContextInfo, nameRegex *regexp.Regexp) []*models.ContextInfo {
+	var matches []*models.ContextInfo
+	for _, c := range items {
+		if nameRegex != nil && !nameRegex.MatchString(c.Name) {
+			continue
+		}
+		matches = append(matches, c)
+	}
+	return matches
+}
+
+func (d *ContextsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_contexts"
+}
+
+func (d *ContextsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Fetches the list of contexts of an owner",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier of this data source: owner ID.",
+				Computed:            true,
+			},
+			"owner": schema.SingleNestedAttribute{
+				MarkdownDescription: "The owner of the contexts",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						MarkdownDescription: "The unique ID of the owner",
+						Required:            true,
+					},
+					"type": schema.StringAttribute{
+						MarkdownDescription: "The type of the owner. Accepts `account` or `organization`. Accounts are only used as context owners in **Server**.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(vOwnerTypes...),
+						},
+					},
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only list contexts whose name matches this regular expression.",
+				Optional:            true,
+			},
+			"include_env_vars": schema.BoolAttribute{
+				MarkdownDescription: "Whether to list the environment variable names of each context. Requires an API call per context.",
+				Optional:            true,
+			},
+			"include_restrictions": schema.BoolAttribute{
+				MarkdownDescription: "Whether to list the restrictions of each context. Requires an API call per context.",
+				Optional:            true,
+			},
+			"contexts": schema.ListNestedAttribute{
+				MarkdownDescription: "List of contexts",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Unique identifier of this context",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "context name.",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "The date and time the context was created",
+							Computed:            true,
+						},
+						"env_var_names": schema.ListAttribute{
+							MarkdownDescription: "The environment variable names of the context, if `include_env_vars` is true",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"restrictions": contextRestrictionsAttribute(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ContextsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ContextsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ContextsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		re, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid name_regex", fmt.Sprintf("%s", err))
+			return
+		}
+		nameRegex = re
+	}
+
+	nextToken := ""
+	ownerId := strfmt.UUID(data.Owner.Id.ValueString())
+	ownerType := data.Owner.Type.ValueString()
+
+	var items []*models.ContextInfo
+	for {
+		param := contexts.NewListContextsParamsWithContext(ctx).WithDefaults()
+		param = param.WithOwnerID(&ownerId).WithOwnerType(ownerType).WithPageToken(&nextToken)
+
+		res, err := d.client.Client.Contexts.ListContexts(param, d.client.Auth)
+		if err != nil {
+			resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
+			return
+		}
+
+		info := res.GetPayload()
+		items = append(items, info.Items...)
+
+		nextToken = info.NextPageToken
+		if nextToken == "" {
+			break
+		}
+	}
+
+	data.Contexts = []contextsItemModel{}
+	for _, c := range filterContexts(items, nameRegex) {
+		id := c.ID.String()
+		item := contextsItemModel{
+			Id:        types.StringValue(id),
+			Name:      types.StringValue(c.Name),
+			CreatedAt: types.StringValue(c.CreatedAt.String()),
+		}
+
+		if data.IncludeEnvVars.ValueBool() {
+			envVars, err := listContextEnvVars(ctx, d.client, id)
+			if err != nil {
+				resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
+				return
+			}
+			item.EnvVarNames = []types.String{}
+			for _, ev := range envVars {
+				item.EnvVarNames = append(item.EnvVarNames, types.StringValue(ev.Variable))
+			}
+		}
+
+		if data.IncludeRestrictions.ValueBool() {
+			restrictions, err := d.client.contextRestrictionModels(ctx, id)
+			if err != nil {
+				resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
+				return
+			}
+			item.Restrictions = restrictions
+		}
+
+		data.Contexts = append(data.Contexts, item)
+	}
+	data.Id = data.Owner.Id
+
+	// Save data into Terraform state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
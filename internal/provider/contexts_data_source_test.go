@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/kelvintaywl/circleci-go-sdk/models"
+)
+
+func TestFilterContexts(t *testing.T) {
+	items := []*models.ContextInfo{
+		{Name: "deploy-prod"},
+		{Name: "deploy-staging"},
+		{Name: "slack"},
+	}
+
+	names := func(cs []*models.ContextInfo) []string {
+		var s []string
+		for _, c := range cs {
+			s = append(s, c.Name)
+		}
+		return s
+	}
+
+	for _, tc := range []struct {
+		name      string
+		nameRegex *regexp.Regexp
+		expected  []string
+	}{
+		{"no filter", nil, []string{"deploy-prod", "deploy-staging", "slack"}},
+		{"name regex", regexp.MustCompile(`^deploy-`), []string{"deploy-prod", "deploy-staging"}},
+		{"no match", regexp.MustCompile(`^none$`), nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := names(filterContexts(items, tc.nameRegex))
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAccContextsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "circleci_contexts" "test" {
+  owner = {
+	id   = "%s"
+	type = "organization"
+  }
+  name_regex           = "^%s$"
+  include_env_vars     = true
+  include_restrictions = true
+}`, orgId, contextName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_contexts.test", "id", orgId),
+					resource.TestCheckResourceAttr("data.circleci_contexts.test", "contexts.#", "1"),
+					resource.TestCheckResourceAttr("data.circleci_contexts.test", "contexts.0.id", contextId),
+					resource.TestCheckResourceAttr("data.circleci_contexts.test", "contexts.0.name", contextName),
+					resource.TestCheckResourceAttrSet("data.circleci_contexts.test", "contexts.0.created_at"),
+					resource.TestCheckResourceAttrSet("data.circleci_contexts.test", "contexts.0.env_var_names.#"),
+					resource.TestCheckResourceAttrSet("data.circleci_contexts.test", "contexts.0.restrictions.#"),
+				),
+			},
+			// Read testing for standalone
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "circleci_contexts" "standalone" {
+  owner = {
+	id   = "%s"
+	type = "organization"
+  }
+}`, standaloneOrgId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_contexts.standalone", "id", standaloneOrgId),
+					resource.TestCheckResourceAttrSet("data.circleci_contexts.standalone", "contexts.#"),
+					resource.TestCheckNoResourceAttr("data.circleci_contexts.standalone", "contexts.0.env_var_names"),
+				),
+			},
+		},
+	})
+}
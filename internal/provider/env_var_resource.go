@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -19,6 +20,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &EnvVarResource{}
+var _ resource.ResourceWithModifyPlan = &EnvVarResource{}
 
 func NewEnvVarResource() resource.Resource {
 	return &EnvVarResource{}
@@ -32,6 +34,8 @@ type EnvVarResourceModel struct {
 	ProjectSlug types.String `tfsdk:"project_slug"`
 	Name        types.String `tfsdk:"name"`
 	Value       types.String `tfsdk:"value"`
+	ValueWO     types.String `tfsdk:"value_wo"`
+	ValueHash   types.String `tfsdk:"value_hash"`
 	Id          types.String `tfsdk:"id"`
 }
 
@@ -54,19 +58,26 @@ func (r *EnvVarResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			"name": schema.StringAttribute{
 				MarkdownDescription: "The name of the environment variable",
 				Required:            true,
-			},
-			"value": schema.StringAttribute{
-				MarkdownDescription: "The value of the environment variable",
-				Required:            true,
-				Sensitive:           true,
 				// if modifed, this requires a replacement instead.
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The value of the environment variable. This is not set when imported, and is written on the next apply.",
+				Optional:            true,
+				Sensitive:           true,
+				// if modifed, this requires a replacement instead (see ModifyPlan).
+			},
+			"value_wo":   secretWriteOnlyAttribute("value", "The value of the environment variable."),
+			"value_hash": secretHashAttribute("value"),
 			"project_slug": schema.StringAttribute{
 				MarkdownDescription: "The project-slug for the environment variable",
 				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 		},
 	}
@@ -126,6 +137,14 @@ func (r *EnvVarResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 }
 
+// ModifyPlan keeps the hashed value (see value_wo) in the plan while it matches the configuration.
+// A changed value requires a replacement, unless it was not known (e.g., imported).
+func (r *EnvVarResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if planSecret(ctx, r.client, req, resp, "value") {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("value"), path.Root("value_hash"))
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *EnvVarResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -141,7 +160,11 @@ func (r *EnvVarResource) Create(ctx context.Context, req resource.CreateRequest,
 	param = param.WithProjectSlug(projectSlug)
 
 	name := plan.Name.ValueString()
-	value := plan.Value.ValueString()
+	value, diags := configSecret(ctx, req.Config, "value")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	body := models.ProjectEnvVarPayload{
 		Name:  &name,
 		Value: &value,
@@ -149,6 +172,9 @@ func (r *EnvVarResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	param = param.WithBody(&body)
 
+	unlock := r.client.lockProject(projectSlug)
+	defer unlock()
+
 	_, err := r.client.Client.Project.AddProjectEnvVar(param, r.client.Auth)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -158,6 +184,12 @@ func (r *EnvVarResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	plan.ValueHash, diags = configSecretHash(ctx, req.Config, "value", plan.ValueHash)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	plan.Id = types.StringValue(fmt.Sprintf("%s/%s", projectSlug, name))
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -168,7 +200,57 @@ func (r *EnvVarResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *EnvVarResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// not implemented; requires a replacement
+	// only reached when the value was not known (e.g., imported), or moved to value_wo;
+	// other changes require a replacement
+	var plan EnvVarResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectSlug := plan.ProjectSlug.ValueString()
+	param := project.NewAddProjectEnvVarParamsWithContext(ctx).WithDefaults()
+	param = param.WithProjectSlug(projectSlug)
+
+	name := plan.Name.ValueString()
+	value, diags := configSecret(ctx, req.Config, "value")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	body := models.ProjectEnvVarPayload{
+		Name:  &name,
+		Value: &value,
+	}
+
+	param = param.WithBody(&body)
+
+	unlock := r.client.lockProject(projectSlug)
+	defer unlock()
+
+	_, err := r.client.Client.Project.AddProjectEnvVar(param, r.client.Auth)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating project env var",
+			fmt.Sprintf("Could not update project(%s) env var %s, unexpected error: %s", projectSlug, name, err.Error()),
+		)
+		return
+	}
+
+	plan.ValueHash, diags = configSecretHash(ctx, req.Config, "value", plan.ValueHash)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s/%s", projectSlug, name))
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 func (r *EnvVarResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -186,6 +268,9 @@ func (r *EnvVarResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	param := project.NewDeleteProjectEnvVarParamsWithContext(ctx).WithDefaults()
 	param = param.WithProjectSlug(projectSlug).WithName(name)
 
+	unlock := r.client.lockProject(projectSlug)
+	defer unlock()
+
 	_, err := r.client.Client.Project.DeleteProjectEnvVar(param, r.client.Auth)
 	if err != nil {
 		errMsg := err.Error()
@@ -201,3 +286,26 @@ func (r *EnvVarResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 }
+
+func (r *EnvVarResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// project slug, name
+	// NOTE: the project slug itself contains slashes, so we split on the last one.
+	i := strings.LastIndex(req.ID, "/")
+
+	if i <= 0 || i == len(req.ID)-1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_slug/name. Got: %q", req.ID),
+		)
+		return
+	}
+
+	projectSlug := req.ID[:i]
+	name := req.ID[i+1:]
+
+	// CircleCI does not return the value, so it is left unset.
+	// The configured value is then written on the next apply.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_slug"), projectSlug)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
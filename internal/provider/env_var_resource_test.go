@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -54,6 +55,13 @@ resource "circleci_env_var" "env2" {
 					resource.TestCheckResourceAttr("circleci_env_var.env2", "id", fmt.Sprintf("%s/FIZZBUZZ", projectSlug)),
 				),
 			},
+			// Test Import
+			{
+				ResourceName:            "circleci_env_var.env2",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"value"},
+			},
 			// Create and Read settings for standalone
 			{
 				Config: providerConfig + fmt.Sprintf(`
@@ -73,3 +81,76 @@ resource "circleci_env_var" "env3" {
 		},
 	})
 }
+
+func TestAccProjectEnvVarResource_writeOnly(t *testing.T) {
+	// requires Terraform 1.11 or later, for write-only attributes
+	providerConfigWithoutSecrets := `
+provider "circleci" {
+  // api_token via CIRCLE_TOKEN env var
+  hostname = "circleci.com"
+  retry = true
+  max_retries = 4
+  store_secrets_in_state = false
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with the value kept as-is in state
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_env_var" "env1" {
+	project_slug = "%s"
+	name         = "WRITE_ONLY"
+	value        = "random1234"
+}
+`, projectSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_env_var.env1", "value", "random1234"),
+					resource.TestCheckNoResourceAttr("circleci_env_var.env1", "value_hash"),
+				),
+			},
+			// Plain values are rejected when store_secrets_in_state is false
+			{
+				Config: providerConfigWithoutSecrets + fmt.Sprintf(`
+resource "circleci_env_var" "env1" {
+	project_slug = "%s"
+	name         = "WRITE_ONLY"
+	value        = "random1234"
+}
+`, projectSlug),
+				ExpectError: regexp.MustCompile(`set .value_wo. instead of .value.`),
+			},
+			// Upgrade: the value in state is replaced by its hash, without replacement
+			{
+				Config: providerConfigWithoutSecrets + fmt.Sprintf(`
+resource "circleci_env_var" "env1" {
+	project_slug = "%s"
+	name         = "WRITE_ONLY"
+	value_wo     = "random1234"
+}
+`, projectSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("circleci_env_var.env1", "value"),
+					resource.TestCheckNoResourceAttr("circleci_env_var.env1", "value_wo"),
+					resource.TestCheckResourceAttrSet("circleci_env_var.env1", "value_hash"),
+					resource.TestCheckResourceAttr("circleci_env_var.env1", "id", fmt.Sprintf("%s/WRITE_ONLY", projectSlug)),
+				),
+			},
+			// Change the write-only value
+			{
+				Config: providerConfigWithoutSecrets + fmt.Sprintf(`
+resource "circleci_env_var" "env1" {
+	project_slug = "%s"
+	name         = "WRITE_ONLY"
+	value_wo     = "changed"
+}
+`, projectSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("circleci_env_var.env1", "value"),
+					resource.TestCheckResourceAttrSet("circleci_env_var.env1", "value_hash"),
+				),
+			},
+		},
+	})
+}
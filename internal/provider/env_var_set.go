@@ -0,0 +1,382 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// envVarSet manages the env vars of a context or a project as a whole,
+// for the circleci_context_env_vars and circleci_project_env_vars resources.
+type envVarSet struct {
+	// describes the owner of the env vars, e.g., "Project(gh/org/repo)"
+	owner string
+	// lists the names of all the env vars of the owner
+	list func(ctx context.Context) ([]string, error)
+	// writes an env var, and returns the date and time it was last updated, if known
+	upsert func(ctx context.Context, name, value string) (string, error)
+	// deletes an env var
+	delete func(ctx context.Context, name string) error
+}
+
+// envVarsInState are the env vars written, as kept in state: either as-is (in variables),
+// or as salted hashes of the write-only values (in variables_hash). Either map is nil when null.
+type envVarsInState struct {
+	values map[string]string
+	hashes map[string]string
+}
+
+// envVarsWriteOnlyAttribute returns the write-only alternative to variables.
+func envVarsWriteOnlyAttribute(description string) schema.MapAttribute {
+	return schema.MapAttribute{
+		MarkdownDescription: fmt.Sprintf("%s Write-only alternative to `variables`, never kept in state (requires Terraform 1.11 or later). Exactly one of `variables` or `variables_wo` must be set.", description),
+		ElementType:         types.StringType,
+		Optional:            true,
+		Sensitive:           true,
+		WriteOnly:           true,
+		Validators: []validator.Map{
+			mapvalidator.ExactlyOneOf(path.MatchRoot("variables")),
+		},
+	}
+}
+
+// envVarsHashAttribute returns the computed salted hashes of variables_wo.
+func envVarsHashAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		MarkdownDescription: "A salted hash of each value of `variables_wo`, as a map of name to hash, to detect changes to them",
+		ElementType:         types.StringType,
+		Computed:            true,
+	}
+}
+
+// envVarsFromState returns the env vars kept in state.
+func envVarsFromState(ctx context.Context, variables, hashes types.Map) (envVarsInState, diag.Diagnostics) {
+	var state envVarsInState
+	var diags diag.Diagnostics
+	if !variables.IsNull() {
+		state.values = map[string]string{}
+		diags.Append(variables.ElementsAs(ctx, &state.values, false)...)
+	}
+	if !hashes.IsNull() {
+		state.hashes = map[string]string{}
+		diags.Append(hashes.ElementsAs(ctx, &state.hashes, false)...)
+	}
+	return state, diags
+}
+
+// toState returns the variables and their hashes to keep in state.
+func (s envVarsInState) toState(ctx context.Context) (types.Map, types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	variables, hashes := types.MapNull(types.StringType), types.MapNull(types.StringType)
+	if s.values != nil {
+		var d diag.Diagnostics
+		variables, d = types.MapValueFrom(ctx, types.StringType, s.values)
+		diags.Append(d...)
+	}
+	if s.hashes != nil {
+		var d diag.Diagnostics
+		hashes, d = types.MapValueFrom(ctx, types.StringType, s.hashes)
+		diags.Append(d...)
+	}
+	return variables, hashes, diags
+}
+
+// names returns the names of the env vars in state.
+func (s envVarsInState) names() map[string]bool {
+	names := map[string]bool{}
+	for name := range s.values {
+		names[name] = true
+	}
+	for name := range s.hashes {
+		names[name] = true
+	}
+	return names
+}
+
+// drop removes an env var from state, so it is written again.
+func (s envVarsInState) drop(name string) {
+	delete(s.values, name)
+	delete(s.hashes, name)
+}
+
+// unchanged returns true if the env var is kept in state with the same value, either as-is or hashed.
+func (s envVarsInState) unchanged(name, value string) bool {
+	prior, hash := types.StringNull(), types.StringNull()
+	if v, ok := s.values[name]; ok {
+		prior = types.StringValue(v)
+	}
+	if h, ok := s.hashes[name]; ok {
+		hash = types.StringValue(h)
+	}
+	return secretInState(value, prior, hash)
+}
+
+// configEnvVars returns the configured env vars, from either variables or variables_wo,
+// and whether they are write-only.
+func configEnvVars(ctx context.Context, config tfsdk.Config) (map[string]string, bool, diag.Diagnostics) {
+	var variables, writeOnly types.Map
+	diags := config.GetAttribute(ctx, path.Root("variables"), &variables)
+	diags.Append(config.GetAttribute(ctx, path.Root("variables_wo"), &writeOnly)...)
+	if diags.HasError() {
+		return nil, false, diags
+	}
+
+	configured := map[string]string{}
+	if !writeOnly.IsNull() {
+		diags.Append(writeOnly.ElementsAs(ctx, &configured, false)...)
+		return configured, true, diags
+	}
+	diags.Append(variables.ElementsAs(ctx, &configured, false)...)
+	return configured, false, diags
+}
+
+// appliedEnvVarHashes returns what to keep in state as the hashes of write-only env vars:
+// the planned hashes, if kept from state, or else new salted hashes of the configured env vars.
+func appliedEnvVarHashes(ctx context.Context, configured map[string]string, writeOnly bool, prior envVarsInState, planned types.Map) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !writeOnly {
+		return types.MapNull(types.StringType), diags
+	}
+	if !planned.IsUnknown() && !planned.IsNull() {
+		return planned, diags
+	}
+
+	hashes := map[string]string{}
+	for name, value := range configured {
+		if hash, ok := prior.hashes[name]; ok && secretMatchesHash(value, hash) {
+			hashes[name] = hash
+			continue
+		}
+		hash, err := hashSecret(value)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("variables_hash"),
+				"Error hashing secret",
+				fmt.Sprintf("Could not hash env var %s for state, unexpected error: %s", name, err.Error()),
+			)
+			return types.MapNull(types.StringType), diags
+		}
+		hashes[name] = hash
+	}
+	return types.MapValueFrom(ctx, types.StringType, hashes)
+}
+
+// unmanagedNames returns the names not found in the managed names, sorted.
+func unmanagedNames(names []string, managed map[string]bool) []string {
+	unmanaged := []string{}
+	for _, name := range names {
+		if !managed[name] {
+			unmanaged = append(unmanaged, name)
+		}
+	}
+	sort.Strings(unmanaged)
+	return unmanaged
+}
+
+// remove deletes an env var, ignoring those no longer found.
+func (s *envVarSet) remove(ctx context.Context, name string) error {
+	err := s.delete(ctx, name)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		tflog.Warn(ctx, fmt.Sprintf("%s env var no longer found: %s", s.owner, name))
+		return nil
+	}
+	return err
+}
+
+// sync writes the planned variables, given the variables in state and when they were last updated.
+// It returns the last updated date and time of each variable, if known, and the names left unmanaged.
+func (s *envVarSet) sync(ctx context.Context, planned map[string]string, prior envVarsInState, priorUpdatedAt map[string]string, exclusive bool) (map[string]string, []string, error) {
+	updatedAt := map[string]string{}
+	for name, value := range planned {
+		if prior.unchanged(name, value) {
+			updatedAt[name] = priorUpdatedAt[name]
+			continue
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Writing %s env var %s", s.owner, name))
+		at, err := s.upsert(ctx, name, value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not write env var %s: %w", name, err)
+		}
+		updatedAt[name] = at
+	}
+
+	for name := range prior.names() {
+		if _, ok := planned[name]; ok {
+			continue
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Deleting %s env var %s", s.owner, name))
+		if err := s.remove(ctx, name); err != nil {
+			return nil, nil, fmt.Errorf("could not delete env var %s: %w", name, err)
+		}
+	}
+
+	names, err := s.list(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not list env vars: %w", err)
+	}
+	managed := map[string]bool{}
+	for name := range planned {
+		managed[name] = true
+	}
+	unmanaged := unmanagedNames(names, managed)
+	if !exclusive {
+		return updatedAt, unmanaged, nil
+	}
+
+	for _, name := range unmanaged {
+		tflog.Info(ctx, fmt.Sprintf("Deleting unmanaged %s env var %s", s.owner, name))
+		if err := s.remove(ctx, name); err != nil {
+			return nil, nil, fmt.Errorf("could not delete unmanaged env var %s: %w", name, err)
+		}
+	}
+	return updatedAt, []string{}, nil
+}
+
+// deleteAll deletes the managed variables only.
+func (s *envVarSet) deleteAll(ctx context.Context, prior envVarsInState) error {
+	for name := range prior.names() {
+		if err := s.remove(ctx, name); err != nil {
+			return fmt.Errorf("could not delete env var %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// planEnvVars plans the hashes of write-only env vars (see variables_wo), keeping them from state while they match the configuration.
+// Other computed attributes which would only change because of the env vars (e.g., updated_at) are then planned as unknown.
+// It also plans and reports the unmanaged env vars of the owner, which are either kept or deleted based on exclusive.
+func planEnvVars(ctx context.Context, client *CircleciAPIClient, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, owner string, volatilePaths ...path.Path) {
+	// nothing to plan when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var variables, writeOnly types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("variables"), &variables)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("variables_wo"), &writeOnly)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if client != nil && client.hashSecrets && !variables.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("variables"),
+			"Secret kept in state",
+			"store_secrets_in_state is false, so set `variables_wo` instead of `variables`, to keep only salted hashes of the env vars in state.",
+		)
+		return
+	}
+
+	configured := variables
+	hashPath := path.Root("variables_hash")
+	if writeOnly.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, hashPath, types.MapNull(types.StringType))...)
+	} else {
+		configured = writeOnly
+		if req.State.Raw.IsNull() || !envVarHashesInState(ctx, req.State, writeOnly) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, hashPath, types.MapUnknown(types.StringType))...)
+			for _, p := range volatilePaths {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p, types.MapUnknown(types.StringType))...)
+			}
+		} else {
+			var priorHashes types.Map
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, hashPath, &priorHashes)...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, hashPath, priorHashes)...)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// nothing to report on create
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var exclusive types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("exclusive"), &exclusive)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if configured.IsUnknown() || exclusive.IsUnknown() {
+		return
+	}
+
+	unmanagedPath := path.Root("unmanaged_names")
+	if exclusive.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, unmanagedPath, []string{})...)
+		return
+	}
+
+	var names []string
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, unmanagedPath, &names)...)
+	planned := map[string]types.String{}
+	resp.Diagnostics.Append(configured.ElementsAs(ctx, &planned, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unmanaged := []string{}
+	for _, name := range names {
+		if _, ok := planned[name]; !ok {
+			unmanaged = append(unmanaged, name)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, unmanagedPath, unmanaged)...)
+
+	if len(unmanaged) > 0 {
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("%s has env vars not managed by Terraform", owner),
+			fmt.Sprintf("These env vars are kept as-is: %s. Set exclusive = true to delete them instead.", strings.Join(unmanaged, ", ")),
+		)
+	}
+}
+
+// envVarHashesInState returns true if the hashes in state match the configured write-only env vars, and no more.
+func envVarHashesInState(ctx context.Context, state tfsdk.State, writeOnly types.Map) bool {
+	if writeOnly.IsUnknown() {
+		return false
+	}
+
+	var priorHashes types.Map
+	configured := map[string]types.String{}
+	prior := map[string]string{}
+	if state.GetAttribute(ctx, path.Root("variables_hash"), &priorHashes).HasError() || priorHashes.IsNull() {
+		return false
+	}
+	if writeOnly.ElementsAs(ctx, &configured, false).HasError() || priorHashes.ElementsAs(ctx, &prior, false).HasError() {
+		return false
+	}
+	if len(configured) != len(prior) {
+		return false
+	}
+	for name, value := range configured {
+		if value.IsUnknown() || !secretMatchesHash(value.ValueString(), prior[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// appliedUnmanagedNames returns the planned unmanaged names when known, since env vars may be added elsewhere in the meantime,
+// or else the unmanaged names found when applying.
+func appliedUnmanagedNames(ctx context.Context, planned types.Set, unmanaged []string) (types.Set, diag.Diagnostics) {
+	if !planned.IsUnknown() {
+		return planned, nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, unmanaged)
+}
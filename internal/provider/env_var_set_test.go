@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testEnvVarSet returns an env var set over the given env vars, recording the writes and deletes.
+func testEnvVarSet(envVars map[string]string) (*envVarSet, *[]string, *[]string) {
+	var written, deleted []string
+	s := &envVarSet{
+		owner: "Project(gh/foo/bar)",
+		list: func(context.Context) ([]string, error) {
+			names := []string{}
+			for name := range envVars {
+				names = append(names, name)
+			}
+			return names, nil
+		},
+		upsert: func(_ context.Context, name, value string) (string, error) {
+			written = append(written, name)
+			envVars[name] = value
+			return "now", nil
+		},
+		delete: func(_ context.Context, name string) error {
+			deleted = append(deleted, name)
+			if _, ok := envVars[name]; !ok {
+				return errors.New("env var not found")
+			}
+			delete(envVars, name)
+			return nil
+		},
+	}
+	return s, &written, &deleted
+}
+
+func TestEnvVarSetSync(t *testing.T) {
+	ctx := context.Background()
+	s, written, deleted := testEnvVarSet(map[string]string{
+		"KEPT":      "same",
+		"HASHED":    "same",
+		"CHANGED":   "old",
+		"REMOVED":   "gone",
+		"UNMANAGED": "other",
+	})
+
+	hash, _ := hashSecret("same")
+	planned := map[string]string{"KEPT": "same", "HASHED": "same", "CHANGED": "new", "ADDED": "new"}
+	prior := envVarsInState{
+		values: map[string]string{"KEPT": "same", "CHANGED": "old", "REMOVED": "gone", "MISSING": "gone"},
+		hashes: map[string]string{"HASHED": hash},
+	}
+	updatedAt, unmanaged, err := s.sync(ctx, planned, prior, map[string]string{"KEPT": "before", "HASHED": "before"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(*written)
+	sort.Strings(*deleted)
+	if !reflect.DeepEqual(*written, []string{"ADDED", "CHANGED"}) {
+		t.Errorf("unexpected writes: %v", *written)
+	}
+	// env vars no longer found are not an error
+	if !reflect.DeepEqual(*deleted, []string{"MISSING", "REMOVED"}) {
+		t.Errorf("unexpected deletes: %v", *deleted)
+	}
+	if !reflect.DeepEqual(updatedAt, map[string]string{"KEPT": "before", "HASHED": "before", "CHANGED": "now", "ADDED": "now"}) {
+		t.Errorf("unexpected updated at: %v", updatedAt)
+	}
+	if !reflect.DeepEqual(unmanaged, []string{"UNMANAGED"}) {
+		t.Errorf("unexpected unmanaged names: %v", unmanaged)
+	}
+
+	// exclusive deletes the unmanaged env vars
+	_, unmanaged, err = s.sync(ctx, planned, envVarsInState{values: planned}, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unmanaged) != 0 || (*deleted)[len(*deleted)-1] != "UNMANAGED" {
+		t.Errorf("expected unmanaged env vars to be deleted, got %v, %v", unmanaged, *deleted)
+	}
+}
+
+func TestAppliedEnvVarHashes(t *testing.T) {
+	ctx := context.Background()
+	hash, _ := hashSecret("same")
+	prior := envVarsInState{hashes: map[string]string{"KEPT": hash, "CHANGED": hash}}
+	configured := map[string]string{"KEPT": "same", "CHANGED": "new"}
+
+	applied, diags := appliedEnvVarHashes(ctx, configured, true, prior, types.MapUnknown(types.StringType))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	hashes := map[string]string{}
+	applied.ElementsAs(ctx, &hashes, false)
+	// hashes of unchanged env vars are kept as-is
+	if hashes["KEPT"] != hash {
+		t.Errorf("expected hash %q to be kept, got %q", hash, hashes["KEPT"])
+	}
+	if !secretMatchesHash("new", hashes["CHANGED"]) {
+		t.Errorf("expected hash of new value, got %q", hashes["CHANGED"])
+	}
+
+	// no hashes of env vars set as-is
+	none, _ := appliedEnvVarHashes(ctx, configured, false, prior, types.MapUnknown(types.StringType))
+	if !none.IsNull() {
+		t.Errorf("expected no hashes, got %s", none)
+	}
+}
@@ -0,0 +1,508 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kelvintaywl/circleci-go-sdk/client/project"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &FollowedProjectsResource{}
+var _ resource.ResourceWithModifyPlan = &FollowedProjectsResource{}
+
+func NewFollowedProjectsResource() resource.Resource {
+	return &FollowedProjectsResource{}
+}
+
+type FollowedProjectsResource struct {
+	client *CircleciAPIClient
+}
+
+type FollowedProjectsResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	OrganizationSlug  types.String `tfsdk:"organization_slug"`
+	Include           types.List   `tfsdk:"include"`
+	Exclude           types.List   `tfsdk:"exclude"`
+	UnfollowUnmatched types.Bool   `tfsdk:"unfollow_unmatched"`
+	Projects          types.Map    `tfsdk:"projects"`
+	Followed          types.Set    `tfsdk:"followed"`
+}
+
+// followedSlugs returns the slugs of the projects followed by this resource, if any.
+func (m FollowedProjectsResourceModel) followedSlugs(ctx context.Context) (map[string]bool, diag.Diagnostics) {
+	var slugs []string
+	diags := m.Followed.ElementsAs(ctx, &slugs, false)
+	followed := map[string]bool{}
+	for _, slug := range slugs {
+		followed[slug] = true
+	}
+	return followed, diags
+}
+
+// v1Repo is a repository listed for the user by the v1.1 API.
+type v1Repo struct {
+	VcsType   string `json:"vcs_type"`
+	Username  string `json:"username"`
+	Name      string `json:"name"`
+	Following bool   `json:"following"`
+}
+
+// slug returns the v2 project slug (e.g., gh/org-name/repo-name) of the repository.
+func (repo v1Repo) slug() string {
+	return fmt.Sprintf("%s/%s/%s", shortVcsSlug(repo.VcsType), repo.Username, repo.Name)
+}
+
+// longVcsSlug returns the long form of a VCS slug (e.g., github for gh), as expected by the v1.1 API.
+func longVcsSlug(vcs string) string {
+	switch vcs {
+	case "gh":
+		return "github"
+	case "bb":
+		return "bitbucket"
+	}
+	return vcs
+}
+
+// matchRepos returns the repositories of an organization (e.g., gh/org-name)
+// whose name matches any include pattern, and no exclude pattern.
+func matchRepos(repos []v1Repo, organizationSlug string, include, exclude []*regexp.Regexp) []v1Repo {
+	vcs, org, _ := strings.Cut(organizationSlug, "/")
+	vcs = shortVcsSlug(vcs)
+
+	matchAny := func(patterns []*regexp.Regexp, name string) bool {
+		for _, re := range patterns {
+			if re.MatchString(name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var matches []v1Repo
+	for _, repo := range repos {
+		if shortVcsSlug(repo.VcsType) != vcs || !strings.EqualFold(repo.Username, org) {
+			continue
+		}
+		if matchAny(include, repo.Name) && !matchAny(exclude, repo.Name) {
+			matches = append(matches, repo)
+		}
+	}
+	return matches
+}
+
+// refreshProjects returns the projects in state still to be tracked, given the matching repositories.
+// Matching projects no longer followed are dropped, so that they are followed again on the next apply.
+// Projects whose repository no longer matches (e.g., renamed or archived) are kept,
+// so that the next apply still unfollows them if followed by this resource and unfollow_unmatched is set.
+func refreshProjects(prior map[string]string, repos []v1Repo) map[string]string {
+	projects := map[string]string{}
+	for projectSlug, id := range prior {
+		projects[projectSlug] = id
+	}
+	for _, repo := range repos {
+		if !repo.Following {
+			delete(projects, repo.slug())
+		}
+	}
+	return projects
+}
+
+// unmatchedFollowed returns the slugs of the projects followed by this resource no longer matching, sorted.
+// Projects followed beforehand are never returned, so that they are never unfollowed.
+func unmatchedFollowed(followed map[string]bool, projects map[string]string) []string {
+	var unmatched []string
+	for projectSlug := range followed {
+		if _, ok := projects[projectSlug]; !ok {
+			unmatched = append(unmatched, projectSlug)
+		}
+	}
+	sort.Strings(unmatched)
+	return unmatched
+}
+
+// compilePatterns compiles the regular expressions of a list attribute.
+func compilePatterns(ctx context.Context, list types.List) ([]*regexp.Regexp, error) {
+	var patterns []string
+	if diags := list.ElementsAs(ctx, &patterns, false); diags.HasError() {
+		return nil, fmt.Errorf("could not read patterns")
+	}
+
+	var res []*regexp.Regexp
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+func (r *FollowedProjectsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_followed_projects"
+}
+
+func (r *FollowedProjectsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Follows every repository of an organization matching name patterns, as projects",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Read-only unique identifier: organization slug",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_slug": schema.StringAttribute{
+				MarkdownDescription: "Organization slug in the form `vcs-slug/org-name` (e.g., `gh/acme` or `github/acme`).",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[^/]+/[^/]+$`),
+						"must be in the form vcs-slug/org-name",
+					),
+				},
+			},
+			"include": schema.ListAttribute{
+				MarkdownDescription: "Regular expressions of the repository names to follow (e.g., `^.*-service$`).",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"exclude": schema.ListAttribute{
+				MarkdownDescription: "Regular expressions of the repository names not to follow, even if included.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"unfollow_unmatched": schema.BoolAttribute{
+				MarkdownDescription: "Whether to unfollow the projects followed by this resource (see `followed`), once their repository no longer matches. Defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"projects": schema.MapAttribute{
+				MarkdownDescription: "The followed projects: project IDs, by project slug",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"followed": schema.SetAttribute{
+				MarkdownDescription: "The slugs of the projects followed by this resource itself. Projects already followed beforehand (e.g., by `circleci_project`) are not, and are never unfollowed by this resource.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *FollowedProjectsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// listV1Repos lists the repositories of the VCS provider of an organization (e.g., gh/org-name)
+// the user of the API token has access to.
+func listV1Repos(ctx context.Context, client *CircleciAPIClient, organizationSlug string) ([]v1Repo, error) {
+	vcs, _, _ := strings.Cut(organizationSlug, "/")
+
+	var repos []v1Repo
+	for page := 1; ; page++ {
+		url := client.apiURL(fmt.Sprintf("/api/v1.1/user/repos/%s?page=%d&per-page=100", longVcsSlug(vcs), page))
+		var items []v1Repo
+		if err := client.doJSON(ctx, http.MethodGet, url, nil, &items); err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return repos, nil
+		}
+		repos = append(repos, items...)
+	}
+}
+
+// matchedRepos lists the repositories of the organization matching the patterns of the model.
+func (r *FollowedProjectsResource) matchedRepos(ctx context.Context, m FollowedProjectsResourceModel) ([]v1Repo, error) {
+	include, err := compilePatterns(ctx, m.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := compilePatterns(ctx, m.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationSlug := m.OrganizationSlug.ValueString()
+	repos, err := listV1Repos(ctx, r.client, organizationSlug)
+	if err != nil {
+		return nil, err
+	}
+	return matchRepos(repos, organizationSlug, include, exclude), nil
+}
+
+// followProjects follows the matching repositories, and unfollows the ones previously followed by this resource
+// no longer matching if enabled. It returns the matching project IDs, by project slug,
+// and the slugs of the projects followed by this resource, i.e., not already followed beforehand.
+func (r *FollowedProjectsResource) followProjects(ctx context.Context, plan FollowedProjectsResourceModel, prior map[string]string, priorFollowed map[string]bool) (map[string]string, []string, error) {
+	repos, err := r.matchedRepos(ctx, plan)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	projects := map[string]string{}
+	followed := []string{}
+	for _, repo := range repos {
+		projectSlug := repo.slug()
+		if !repo.Following {
+			tflog.Info(ctx, fmt.Sprintf("Following project %s", projectSlug))
+			url := r.client.apiURL(fmt.Sprintf("/api/v1.1/project/%s/follow", projectSlug))
+			if err := r.client.doJSON(ctx, http.MethodPost, url, nil, nil); err != nil {
+				return nil, nil, fmt.Errorf("could not follow project (%s): %w", projectSlug, err)
+			}
+		}
+		if !repo.Following || priorFollowed[projectSlug] {
+			followed = append(followed, projectSlug)
+		}
+
+		if id, ok := prior[projectSlug]; ok {
+			projects[projectSlug] = id
+			continue
+		}
+		param := project.NewGetProjectParamsWithContext(ctx).WithDefaults()
+		param = param.WithProjectSlug(projectSlug)
+		res, err := r.client.Client.Project.GetProject(param, r.client.Auth)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not read project (%s): %w", projectSlug, err)
+		}
+		projects[projectSlug] = res.GetPayload().ID.String()
+	}
+
+	if plan.UnfollowUnmatched.ValueBool() {
+		for _, projectSlug := range unmatchedFollowed(priorFollowed, projects) {
+			tflog.Info(ctx, fmt.Sprintf("Unfollowing project %s", projectSlug))
+			url := r.client.apiURL(fmt.Sprintf("/api/v1.1/project/%s/unfollow", projectSlug))
+			if err := r.client.doJSON(ctx, http.MethodPost, url, nil, nil); err != nil && !isNotFound(err) {
+				return nil, nil, fmt.Errorf("could not unfollow project (%s): %w", projectSlug, err)
+			}
+		}
+	}
+
+	sort.Strings(followed)
+	return projects, followed, nil
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *FollowedProjectsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state FollowedProjectsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := map[string]string{}
+	diags = state.Projects.ElementsAs(ctx, &prior, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repos, err := r.matchedRepos(ctx, state)
+	if err != nil {
+		resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
+		return
+	}
+
+	state.Projects, diags = types.MapValueFrom(ctx, types.StringType, refreshProjects(prior, repos))
+	resp.Diagnostics.Append(diags...)
+	state.Id = state.OrganizationSlug
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan plans an update when repositories newly match, or are no longer followed.
+func (r *FollowedProjectsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to compare when creating or destroying
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state FollowedProjectsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Include.IsUnknown() || plan.Exclude.IsUnknown() || plan.Projects.IsUnknown() {
+		return
+	}
+
+	repos, err := r.matchedRepos(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
+		return
+	}
+
+	prior := state.Projects.Elements()
+	changed := len(repos) != len(prior)
+	for _, repo := range repos {
+		if _, ok := prior[repo.slug()]; !ok || !repo.Following {
+			changed = true
+		}
+	}
+	if changed {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("projects"), types.MapUnknown(types.StringType))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("followed"), types.SetUnknown(types.StringType))...)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *FollowedProjectsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan FollowedProjectsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projects, followed, err := r.followProjects(ctx, plan, nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error following projects",
+			fmt.Sprintf("Could not follow projects, unexpected error: %s", err.Error()),
+		)
+		return
+	}
+
+	plan.Id = plan.OrganizationSlug
+	plan.Projects, diags = types.MapValueFrom(ctx, types.StringType, projects)
+	resp.Diagnostics.Append(diags...)
+	plan.Followed, diags = types.SetValueFrom(ctx, types.StringType, followed)
+	resp.Diagnostics.Append(diags...)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *FollowedProjectsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state FollowedProjectsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := map[string]string{}
+	diags = state.Projects.ElementsAs(ctx, &prior, false)
+	resp.Diagnostics.Append(diags...)
+	priorFollowed, diags := state.followedSlugs(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projects, followed, err := r.followProjects(ctx, plan, prior, priorFollowed)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error following projects",
+			fmt.Sprintf("Could not follow projects, unexpected error: %s", err.Error()),
+		)
+		return
+	}
+
+	plan.Id = plan.OrganizationSlug
+	plan.Projects, diags = types.MapValueFrom(ctx, types.StringType, projects)
+	resp.Diagnostics.Append(diags...)
+	plan.Followed, diags = types.SetValueFrom(ctx, types.StringType, followed)
+	resp.Diagnostics.Append(diags...)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *FollowedProjectsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state FollowedProjectsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.UnfollowUnmatched.ValueBool() {
+		tflog.Warn(ctx, "Followed projects are left as-is; set unfollow_unmatched to unfollow them on destroy.")
+		return
+	}
+
+	followed, diags := state.followedSlugs(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// every project no longer matches once destroyed; only the ones followed by this resource are unfollowed
+	for projectSlug := range followed {
+		url := r.client.apiURL(fmt.Sprintf("/api/v1.1/project/%s/unfollow", projectSlug))
+		if err := r.client.doJSON(ctx, http.MethodPost, url, nil, nil); err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError(fmt.Sprintf("Encountered error unfollowing project (%s)", projectSlug), fmt.Sprintf("%s", err))
+			return
+		}
+	}
+}
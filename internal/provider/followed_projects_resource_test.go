@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestMatchRepos(t *testing.T) {
+	repos := []v1Repo{
+		{VcsType: "github", Username: "acme", Name: "billing-service"},
+		{VcsType: "github", Username: "acme", Name: "search-service", Following: true},
+		{VcsType: "github", Username: "acme", Name: "legacy-service"},
+		{VcsType: "github", Username: "acme", Name: "website"},
+		{VcsType: "github", Username: "other", Name: "billing-service"},
+	}
+
+	slugs := func(rs []v1Repo) []string {
+		var s []string
+		for _, r := range rs {
+			s = append(s, r.slug())
+		}
+		return s
+	}
+
+	for _, tc := range []struct {
+		name     string
+		org      string
+		include  []*regexp.Regexp
+		exclude  []*regexp.Regexp
+		expected []string
+	}{
+		{
+			"include",
+			"gh/acme",
+			[]*regexp.Regexp{regexp.MustCompile(`-service$`)},
+			nil,
+			[]string{"gh/acme/billing-service", "gh/acme/search-service", "gh/acme/legacy-service"},
+		},
+		{
+			"exclude",
+			"github/acme",
+			[]*regexp.Regexp{regexp.MustCompile(`-service$`)},
+			[]*regexp.Regexp{regexp.MustCompile(`^legacy-`)},
+			[]string{"gh/acme/billing-service", "gh/acme/search-service"},
+		},
+		{
+			"multiple includes",
+			"gh/acme",
+			[]*regexp.Regexp{regexp.MustCompile(`^billing-`), regexp.MustCompile(`^website$`)},
+			nil,
+			[]string{"gh/acme/billing-service", "gh/acme/website"},
+		},
+		{
+			"other organization",
+			"bb/acme",
+			[]*regexp.Regexp{regexp.MustCompile(`.*`)},
+			nil,
+			nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := slugs(matchRepos(repos, tc.org, tc.include, tc.exclude))
+			if fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRefreshProjects(t *testing.T) {
+	prior := map[string]string{
+		"gh/acme/billing-service": "1",
+		"gh/acme/search-service":  "2",
+		"gh/acme/legacy-service":  "3",
+	}
+	repos := []v1Repo{
+		{VcsType: "github", Username: "acme", Name: "billing-service", Following: true},
+		{VcsType: "github", Username: "acme", Name: "search-service"},
+		{VcsType: "github", Username: "acme", Name: "new-service"},
+	}
+
+	// unfollowed projects are dropped, to be followed again;
+	// projects no longer matching are kept, to be unfollowed.
+	expected := map[string]string{
+		"gh/acme/billing-service": "1",
+		"gh/acme/legacy-service":  "3",
+	}
+	if got := refreshProjects(prior, repos); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestUnmatchedFollowed(t *testing.T) {
+	followed := map[string]bool{
+		"gh/acme/billing-service": true,
+		"gh/acme/legacy-service":  true,
+		"gh/acme/old-service":     true,
+	}
+	// search-service was already followed, e.g., by circleci_project
+	projects := map[string]string{
+		"gh/acme/billing-service": "1",
+		"gh/acme/search-service":  "2",
+	}
+
+	expected := []string{"gh/acme/legacy-service", "gh/acme/old-service"}
+	if got := unmatchedFollowed(followed, projects); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestAccFollowedProjectsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "circleci_followed_projects" "fp1" {
+	organization_slug = "gh/kelvintaywl-tf"
+	include           = ["^tf-provider-acceptance-test-dummy$"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_followed_projects.fp1", "id", "gh/kelvintaywl-tf"),
+					resource.TestCheckResourceAttr("circleci_followed_projects.fp1", "unfollow_unmatched", "false"),
+					resource.TestCheckResourceAttr("circleci_followed_projects.fp1", "projects.%", "1"),
+					resource.TestCheckResourceAttr("circleci_followed_projects.fp1", "projects.gh/kelvintaywl-tf/tf-provider-acceptance-test-dummy", projectId),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	// writes are not serialized
+	writeLockNone string = "none"
+	// writes are serialized per context ID or project slug
+	writeLockKeyed string = "keyed"
+	// all writes are serialized
+	writeLockGlobal string = "global"
+)
+
+var vWriteLockGranularities = []string{
+	writeLockNone,
+	writeLockKeyed,
+	writeLockGlobal,
+}
+
+// keyedMutex serializes callers sharing the same key, while other keys proceed in parallel.
+type keyedMutex struct {
+	granularity string
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newKeyedMutex(granularity string) *keyedMutex {
+	return &keyedMutex{
+		granularity: granularity,
+		locks:       map[string]*sync.Mutex{},
+	}
+}
+
+// Lock blocks until the lock for the key is held, and returns the function to release it.
+func (m *keyedMutex) Lock(key string) func() {
+	switch m.granularity {
+	case writeLockNone:
+		return func() {}
+	case writeLockGlobal:
+		key = ""
+	}
+
+	m.mu.Lock()
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.locks[key] = lock
+	}
+	m.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// lockContext serializes writes to the env vars of a context.
+func (c *CircleciAPIClient) lockContext(contextId string) func() {
+	if c.writeLocks == nil {
+		return func() {}
+	}
+	return c.writeLocks.Lock(fmt.Sprintf("context/%s", contextId))
+}
+
+// lockProject serializes writes to the env vars and keys of a project.
+func (c *CircleciAPIClient) lockProject(projectSlug string) func() {
+	if c.writeLocks == nil {
+		return func() {}
+	}
+	return c.writeLocks.Lock(fmt.Sprintf("project/%s", projectSlug))
+}
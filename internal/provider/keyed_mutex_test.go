@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	type testcase struct {
+		granularity string
+		keys        []string
+		// maximum number of holders expected at once
+		expected int32
+	}
+
+	testcases := []testcase{
+		{writeLockKeyed, []string{"context/foo", "context/foo", "context/foo"}, 1},
+		{writeLockKeyed, []string{"context/foo", "context/bar", "project/foo"}, 3},
+		{writeLockGlobal, []string{"context/foo", "context/bar", "project/foo"}, 1},
+		{writeLockNone, []string{"context/foo", "context/foo", "context/foo"}, 3},
+	}
+
+	for _, tc := range testcases {
+		m := newKeyedMutex(tc.granularity)
+
+		var holders, maxHolders int32
+		var started, wg sync.WaitGroup
+		release := make(chan struct{})
+		started.Add(len(tc.keys))
+		for _, key := range tc.keys {
+			wg.Add(1)
+			go func(key string) {
+				defer wg.Done()
+				started.Done()
+				unlock := m.Lock(key)
+				defer unlock()
+
+				n := atomic.AddInt32(&holders, 1)
+				for {
+					max := atomic.LoadInt32(&maxHolders)
+					if n <= max || atomic.CompareAndSwapInt32(&maxHolders, max, n) {
+						break
+					}
+				}
+				if n == tc.expected {
+					// enough holders at once; let everyone finish
+					select {
+					case <-release:
+					default:
+						close(release)
+					}
+				}
+				<-release
+				atomic.AddInt32(&holders, -1)
+			}(key)
+		}
+		started.Wait()
+		wg.Wait()
+
+		if maxHolders != tc.expected {
+			t.Errorf("%s lock on %v: expected %d holders at most, got %d", tc.granularity, tc.keys, tc.expected, maxHolders)
+		}
+	}
+}
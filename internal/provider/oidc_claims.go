@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/kelvintaywl/circleci-go-sdk/models"
+)
+
+// ttlPartRegex matches the parts of a JSON duration (e.g., 1d12h).
+var ttlPartRegex = regexp.MustCompile(`([0-9]+)(ms|s|m|h|d|w)`)
+
+// parseTTL parses a positive JSON duration (e.g., 1h or 20d), as used for OIDC token TTLs.
+func parseTTL(ttl string) (time.Duration, error) {
+	if ttl == "" || ttlPartRegex.ReplaceAllString(ttl, "") != "" {
+		return 0, fmt.Errorf("invalid duration %q", ttl)
+	}
+
+	units := map[string]time.Duration{
+		"ms": time.Millisecond,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+		"d":  24 * time.Hour,
+		"w":  7 * 24 * time.Hour,
+	}
+	var d time.Duration
+	for _, part := range ttlPartRegex.FindAllStringSubmatch(ttl, -1) {
+		n, err := strconv.Atoi(part[1])
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(n) * units[part[2]]
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", ttl)
+	}
+	return d, nil
+}
+
+// ttlValidator validates that a string is a positive JSON duration, as parsed by parseTTL.
+type ttlValidator struct{}
+
+func (v ttlValidator) Description(ctx context.Context) string {
+	return "must be a positive duration (e.g., 1h or 1d)"
+}
+
+func (v ttlValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ttlValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := parseTTL(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// sameTTL returns true if both JSON durations are equal (e.g., 1d and 24h).
+func sameTTL(a, b string) bool {
+	da, errA := parseTTL(a)
+	db, errB := parseTTL(b)
+	return errA == nil && errB == nil && da == db
+}
+
+// oidcClaimsAttributes returns the schema attributes of OIDC custom claims, shared by organizations and projects.
+func oidcClaimsAttributes(level string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"audience": schema.SetAttribute{
+			MarkdownDescription: fmt.Sprintf("The `aud` claims of OIDC tokens issued for the %s. If not set, the default is kept.", level),
+			ElementType:         types.StringType,
+			Optional:            true,
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+				setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				setvalidator.AtLeastOneOf(path.MatchRoot("ttl")),
+			},
+		},
+		"ttl": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("The time-to-live of OIDC tokens issued for the %s, as a positive duration (e.g., `1h` or `1d`). If not set, the default is kept.", level),
+			Optional:            true,
+			Validators: []validator.String{
+				ttlValidator{},
+			},
+		},
+		"audience_updated_at": schema.StringAttribute{
+			MarkdownDescription: "Date-time the audience was last updated",
+			Computed:            true,
+		},
+		"ttl_updated_at": schema.StringAttribute{
+			MarkdownDescription: "Date-time the TTL was last updated",
+			Computed:            true,
+		},
+	}
+}
+
+// oidcClaimsPayload is the payload to set OIDC custom claims.
+// Unlike the SDK's, it omits the claims not configured, instead of sending them as null.
+type oidcClaimsPayload struct {
+	Audience []string `json:"audience,omitempty"`
+	TTL      string   `json:"ttl,omitempty"`
+}
+
+// newOIDCClaimsPayload returns the payload to set the configured claims.
+func newOIDCClaimsPayload(ctx context.Context, audience types.Set, ttl types.String) (oidcClaimsPayload, diag.Diagnostics) {
+	var payload oidcClaimsPayload
+	var diags diag.Diagnostics
+	if !audience.IsNull() {
+		diags = audience.ElementsAs(ctx, &payload.Audience, false)
+	}
+	payload.TTL = ttl.ValueString()
+	return payload, diags
+}
+
+// removedOIDCClaims returns the claims (e.g., audience,ttl) set in state but no longer planned, to restore their defaults.
+func removedOIDCClaims(planAudience, stateAudience types.Set, planTTL, stateTTL types.String) string {
+	var claims []string
+	if planAudience.IsNull() && !stateAudience.IsNull() {
+		claims = append(claims, "audience")
+	}
+	if planTTL.IsNull() && !stateTTL.IsNull() {
+		claims = append(claims, "ttl")
+	}
+	return strings.Join(claims, ",")
+}
+
+// refreshOIDCClaims sets the managed claims from the API. All claims are managed once imported.
+func refreshOIDCClaims(ctx context.Context, info models.OrgLevelClaimsInfo, audience *types.Set, ttl *types.String, audienceUpdatedAt, ttlUpdatedAt *types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	imported := audience.IsNull() && ttl.IsNull()
+
+	if !audience.IsNull() || (imported && len(info.Audience) > 0) {
+		*audience, diags = types.SetValueFrom(ctx, types.StringType, info.Audience)
+	}
+	if !ttl.IsNull() || (imported && info.TTL != "") {
+		if !sameTTL(ttl.ValueString(), info.TTL) {
+			*ttl = types.StringValue(info.TTL)
+		}
+	}
+	*audienceUpdatedAt = types.StringValue(info.AudienceUpdatedAt.String())
+	*ttlUpdatedAt = types.StringValue(info.TTLUpdatedAt.String())
+	return diags
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseTTL(t *testing.T) {
+	for ttl, expected := range map[string]time.Duration{
+		"1h":    time.Hour,
+		"90m":   90 * time.Minute,
+		"1d":    24 * time.Hour,
+		"1d12h": 36 * time.Hour,
+		"2w":    14 * 24 * time.Hour,
+		"500ms": 500 * time.Millisecond,
+	} {
+		got, err := parseTTL(ttl)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", ttl, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("%s: expected %s, got %s", ttl, expected, got)
+		}
+	}
+
+	for _, ttl := range []string{"", "1", "h", "1y", "1h 2m", "-1h", "0s", "0ms", "0h0m"} {
+		if _, err := parseTTL(ttl); err == nil {
+			t.Errorf("%q: expected an error", ttl)
+		}
+	}
+
+	if !sameTTL("1d", "24h") {
+		t.Error("expected 1d and 24h to be the same TTL")
+	}
+	if sameTTL("1h", "2h") || sameTTL("", "") {
+		t.Error("expected different or invalid TTLs not to be the same")
+	}
+}
+
+func TestTTLValidator(t *testing.T) {
+	ctx := context.Background()
+	for ttl, valid := range map[string]bool{
+		"1d12h": true,
+		"500ms": true,
+		"1y":    false,
+		"1h 2m": false,
+		"0s":    false,
+	} {
+		resp := &validator.StringResponse{}
+		ttlValidator{}.ValidateString(ctx, validator.StringRequest{
+			Path:        path.Root("ttl"),
+			ConfigValue: types.StringValue(ttl),
+		}, resp)
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("%q: expected valid to be %t, got diagnostics %v", ttl, valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestNewOIDCClaimsPayload(t *testing.T) {
+	ctx := context.Background()
+	audience := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("foo")})
+
+	for _, tc := range []struct {
+		audience types.Set
+		ttl      types.String
+		expected string
+	}{
+		{audience, types.StringValue("1h"), `{"audience":["foo"],"ttl":"1h"}`},
+		{audience, types.StringNull(), `{"audience":["foo"]}`},
+		{types.SetNull(types.StringType), types.StringValue("1h"), `{"ttl":"1h"}`},
+	} {
+		payload, diags := newOIDCClaimsPayload(ctx, tc.audience, tc.ttl)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		b, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if string(b) != tc.expected {
+			t.Errorf("expected %s, got %s", tc.expected, b)
+		}
+	}
+}
+
+func TestRemovedOIDCClaims(t *testing.T) {
+	audience := types.SetValueMust(types.StringType, nil)
+	noAudience := types.SetNull(types.StringType)
+	ttl := types.StringValue("1h")
+	noTTL := types.StringNull()
+
+	for _, tc := range []struct {
+		planAudience, stateAudience types.Set
+		planTTL, stateTTL           types.String
+		expected                    string
+	}{
+		{audience, audience, ttl, ttl, ""},
+		{noAudience, audience, ttl, ttl, "audience"},
+		{audience, audience, noTTL, ttl, "ttl"},
+		{noAudience, audience, noTTL, ttl, "audience,ttl"},
+		{audience, noAudience, ttl, noTTL, ""},
+	} {
+		if got := removedOIDCClaims(tc.planAudience, tc.stateAudience, tc.planTTL, tc.stateTTL); got != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, got)
+		}
+	}
+}
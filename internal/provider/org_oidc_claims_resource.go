@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kelvintaywl/circleci-go-sdk/client/oidc"
+	"github.com/kelvintaywl/circleci-go-sdk/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &OrgOIDCClaimsResource{}
+
+func NewOrgOIDCClaimsResource() resource.Resource {
+	return &OrgOIDCClaimsResource{}
+}
+
+type OrgOIDCClaimsResource struct {
+	client *CircleciAPIClient
+}
+
+type OrgOIDCClaimsResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	OrgID             types.String `tfsdk:"org_id"`
+	Audience          types.Set    `tfsdk:"audience"`
+	TTL               types.String `tfsdk:"ttl"`
+	AudienceUpdatedAt types.String `tfsdk:"audience_updated_at"`
+	TTLUpdatedAt      types.String `tfsdk:"ttl_updated_at"`
+}
+
+func (r *OrgOIDCClaimsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_oidc_claims"
+}
+
+func (r *OrgOIDCClaimsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := oidcClaimsAttributes("organization")
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "Read-only unique identifier: organization ID",
+		Computed:            true,
+		// unchanged even during updates
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["org_id"] = schema.StringAttribute{
+		MarkdownDescription: "The unique ID of the organization",
+		Required:            true,
+		// if modifed, this requires a replacement instead.
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the OIDC custom claims of an organization",
+		Attributes:          attributes,
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *OrgOIDCClaimsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *OrgOIDCClaimsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state OrgOIDCClaimsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := state.OrgID.ValueString()
+	param := oidc.NewGetOrgLevelClaimsParamsWithContext(ctx).WithDefaults()
+	param = param.WithOrgID(strfmt.UUID(orgID))
+
+	res, err := r.client.Client.Oidc.GetOrgLevelClaims(param, r.client.Auth)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error reading Organization(%s) OIDC claims", orgID), fmt.Sprintf("%s", err))
+		return
+	}
+
+	diags = refreshOIDCClaims(ctx, *res.GetPayload(), &state.Audience, &state.TTL, &state.AudienceUpdatedAt, &state.TTLUpdatedAt)
+	resp.Diagnostics.Append(diags...)
+	state.Id = state.OrgID
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// write sets the planned claims, and restores the defaults of the claims no longer planned.
+func (r *OrgOIDCClaimsResource) write(ctx context.Context, plan *OrgOIDCClaimsResourceModel, state *OrgOIDCClaimsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	orgID := strfmt.UUID(plan.OrgID.ValueString())
+
+	if state != nil {
+		if claims := removedOIDCClaims(plan.Audience, state.Audience, plan.TTL, state.TTL); claims != "" {
+			param := oidc.NewDeleteOrgLevelClaimsParamsWithContext(ctx).WithDefaults()
+			param = param.WithOrgID(orgID).WithClaims(claims)
+			if _, err := r.client.Client.Oidc.DeleteOrgLevelClaims(param, r.client.Auth); err != nil {
+				diags.AddError(
+					"Error updating organization OIDC claims",
+					fmt.Sprintf("Could not restore organization OIDC claims (%s), unexpected error: %s", claims, err.Error()),
+				)
+				return diags
+			}
+		}
+	}
+
+	body, d := newOIDCClaimsPayload(ctx, plan.Audience, plan.TTL)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	var info models.OrgLevelClaimsInfo
+	url := r.client.apiURL(fmt.Sprintf("/api/v2/org/%s/oidc-custom-claims", orgID))
+	if err := r.client.doJSON(ctx, http.MethodPatch, url, body, &info); err != nil {
+		diags.AddError(
+			"Error updating organization OIDC claims",
+			fmt.Sprintf("Could not update organization OIDC claims, unexpected error: %s", err.Error()),
+		)
+		return diags
+	}
+
+	diags.Append(refreshOIDCClaims(ctx, info, &plan.Audience, &plan.TTL, &plan.AudienceUpdatedAt, &plan.TTLUpdatedAt)...)
+	plan.Id = plan.OrgID
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *OrgOIDCClaimsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan OrgOIDCClaimsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.write(ctx, &plan, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *OrgOIDCClaimsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state OrgOIDCClaimsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.write(ctx, &plan, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *OrgOIDCClaimsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state OrgOIDCClaimsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// restores the defaults
+	orgID := state.OrgID.ValueString()
+	param := oidc.NewDeleteOrgLevelClaimsParamsWithContext(ctx).WithDefaults()
+	param = param.WithOrgID(strfmt.UUID(orgID)).WithClaims("audience,ttl")
+
+	if _, err := r.client.Client.Oidc.DeleteOrgLevelClaims(param, r.client.Auth); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting organization OIDC claims",
+			fmt.Sprintf("Could not delete organization(%s) OIDC claims, unexpected error: %s", orgID, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *OrgOIDCClaimsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOrgOIDCClaimsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_org_oidc_claims" "c1" {
+	org_id   = "%s"
+	audience = ["sts.amazonaws.com"]
+	ttl      = "1h"
+}
+`, orgId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_org_oidc_claims.c1", "id", orgId),
+					resource.TestCheckResourceAttr("circleci_org_oidc_claims.c1", "audience.#", "1"),
+					resource.TestCheckTypeSetElemAttr("circleci_org_oidc_claims.c1", "audience.*", "sts.amazonaws.com"),
+					resource.TestCheckResourceAttr("circleci_org_oidc_claims.c1", "ttl", "1h"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "circleci_org_oidc_claims.c1",
+				ImportState:       true,
+				ImportStateId:     orgId,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_org_oidc_claims" "c1" {
+	org_id = "%s"
+	ttl    = "2h"
+}
+`, orgId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("circleci_org_oidc_claims.c1", "audience"),
+					resource.TestCheckResourceAttr("circleci_org_oidc_claims.c1", "ttl", "2h"),
+				),
+			},
+		},
+	})
+}
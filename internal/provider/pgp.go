@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// references a Keybase user's PGP key, found in the local keyring (see pgp_keyring)
+	keybasePrefix string = "keybase:"
+	// email of Keybase users, as in the identities of their PGP keys
+	keybaseEmailDomain string = "@keybase.io"
+)
+
+// parsePGPKey parses the PGP public key, given as either a base64-encoded binary public key, an armored public key,
+// or "keybase:<username>", resolved against the local keyring file (see pgp_keyring).
+// The key must be able to encrypt.
+func parsePGPKey(pgpKey, keyringFile string) (*openpgp.Entity, error) {
+	var keyring openpgp.EntityList
+	var err error
+
+	switch {
+	case strings.HasPrefix(pgpKey, keybasePrefix):
+		keyring, err = keybasePGPKey(strings.TrimPrefix(pgpKey, keybasePrefix), keyringFile)
+		if err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(strings.TrimSpace(pgpKey), "-----BEGIN PGP PUBLIC KEY BLOCK-----"):
+		keyring, err = openpgp.ReadArmoredKeyRing(strings.NewReader(pgpKey))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse armored PGP key: %w", err)
+		}
+	default:
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(pgpKey))
+		if err != nil {
+			return nil, fmt.Errorf("could not decode base64 PGP key: %w", err)
+		}
+		keyring, err = openpgp.ReadKeyRing(bytes.NewReader(key))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse PGP key: %w", err)
+		}
+	}
+
+	if len(keyring) != 1 {
+		return nil, fmt.Errorf("expected exactly one PGP key, got %d", len(keyring))
+	}
+	if _, ok := keyring[0].EncryptionKey(time.Now()); !ok {
+		return nil, fmt.Errorf("PGP key %s has no valid encryption key", pgpFingerprint(keyring[0]))
+	}
+	return keyring[0], nil
+}
+
+// keybasePGPKey returns the PGP keys of the Keybase user found in the local keyring file, either armored or binary.
+// Keys are not fetched from Keybase: export them first, e.g., via `keybase pgp export -q <key-id> >> keyring.asc`.
+// A key is the user's if one of its identities is named after the user, or has the user's Keybase email.
+func keybasePGPKey(username, keyringFile string) (openpgp.EntityList, error) {
+	if username == "" {
+		return nil, fmt.Errorf("expected %s<username>, got no username", keybasePrefix)
+	}
+	if keyringFile == "" {
+		return nil, fmt.Errorf("could not resolve %s%s: set pgp_keyring on the provider to a local keyring with the PGP key of the Keybase user, as keys are not fetched from Keybase", keybasePrefix, username)
+	}
+
+	content, err := os.ReadFile(keyringFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read PGP keyring %s: %w", keyringFile, err)
+	}
+	var keyring openpgp.EntityList
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("-----BEGIN PGP PUBLIC KEY BLOCK-----")) {
+		keyring, err = openpgp.ReadArmoredKeyRing(bytes.NewReader(content))
+	} else {
+		keyring, err = openpgp.ReadKeyRing(bytes.NewReader(content))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse PGP keyring %s: %w", keyringFile, err)
+	}
+
+	var matches openpgp.EntityList
+	for _, entity := range keyring {
+		for _, identity := range entity.Identities {
+			if identity.UserId.Name == username || strings.EqualFold(identity.UserId.Email, username+keybaseEmailDomain) {
+				matches = append(matches, entity)
+				break
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("could not find the PGP key of Keybase user %s in PGP keyring %s", username, keyringFile)
+	}
+	return matches, nil
+}
+
+// pgpFingerprint returns the hex-encoded fingerprint of the PGP key.
+func pgpFingerprint(entity *openpgp.Entity) string {
+	return hex.EncodeToString(entity.PrimaryKey.Fingerprint)
+}
+
+// encryptWithPGPKey encrypts the plaintext for the PGP key, as a base64-encoded binary message.
+// Decrypt it with e.g., `base64 --decode | gpg --decrypt`.
+func encryptWithPGPKey(entity *openpgp.Entity, plaintext string) (string, error) {
+	buf := &bytes.Buffer{}
+	w, err := openpgp.Encrypt(buf, []*openpgp.Entity{entity}, nil, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write([]byte(plaintext)); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// planPGPKey validates the PGP key (see pgp_key), so that a generated secret (e.g., a token) is never created without a way to encrypt it.
+// When store_secrets_in_state is false, the PGP key is required, so that the secret is only kept encrypted in state.
+func planPGPKey(ctx context.Context, client *CircleciAPIClient, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, secret string) {
+	// nothing to validate when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var pgpKey types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("pgp_key"), &pgpKey)...)
+	if resp.Diagnostics.HasError() || pgpKey.IsUnknown() {
+		return
+	}
+
+	if pgpKey.ValueString() == "" {
+		if client != nil && client.hashSecrets {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("pgp_key"),
+				"Secret kept in state",
+				fmt.Sprintf("store_secrets_in_state is false, so set `pgp_key`, to keep the %s only encrypted in state.", secret),
+			)
+		}
+		return
+	}
+
+	if _, err := parsePGPKey(pgpKey.ValueString(), client.pgpKeyringFile()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pgp_key"),
+			"Invalid PGP key",
+			fmt.Sprintf("Could not parse PGP key, unexpected error: %s", err.Error()),
+		)
+	}
+}
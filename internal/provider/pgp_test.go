@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+func TestEncryptWithPGPKey(t *testing.T) {
+	entity, err := openpgp.NewEntity("runner", "", "runner@example.com", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	public := &bytes.Buffer{}
+	if err := entity.Serialize(public); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	armored := &bytes.Buffer{}
+	w, _ := armor.Encode(armored, openpgp.PublicKeyType, nil)
+	_ = entity.Serialize(w)
+	w.Close()
+
+	for _, pgpKey := range []string{
+		base64.StdEncoding.EncodeToString(public.Bytes()),
+		armored.String(),
+	} {
+		key, err := parsePGPKey(pgpKey, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if pgpFingerprint(key) != pgpFingerprint(entity) {
+			t.Errorf("expected fingerprint %s, got %s", pgpFingerprint(entity), pgpFingerprint(key))
+		}
+
+		encrypted, err := encryptWithPGPKey(key, "s3cr3t")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		ciphertext, _ := base64.StdEncoding.DecodeString(encrypted)
+		md, err := openpgp.ReadMessage(bytes.NewReader(ciphertext), openpgp.EntityList{entity}, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		plaintext, _ := io.ReadAll(md.UnverifiedBody)
+		if string(plaintext) != "s3cr3t" {
+			t.Errorf("expected decrypted token, got %q", plaintext)
+		}
+	}
+
+	if _, err := parsePGPKey("not-a-key", ""); err == nil {
+		t.Errorf("expected error for invalid PGP key")
+	}
+}
+
+func TestKeybasePGPKey(t *testing.T) {
+	alice, _ := openpgp.NewEntity("alice", "", "alice@example.com", nil)
+	bob, _ := openpgp.NewEntity("Bob", "", "bob@keybase.io", nil)
+
+	// a local keyring, as exported from Keybase
+	keyring := &bytes.Buffer{}
+	w, _ := armor.Encode(keyring, openpgp.PublicKeyType, nil)
+	_ = alice.Serialize(w)
+	_ = bob.Serialize(w)
+	w.Close()
+	keyringFile := filepath.Join(t.TempDir(), "keyring.asc")
+	if err := os.WriteFile(keyringFile, keyring.Bytes(), 0600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for username, entity := range map[string]*openpgp.Entity{"alice": alice, "bob": bob} {
+		key, err := parsePGPKey("keybase:"+username, keyringFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if pgpFingerprint(key) != pgpFingerprint(entity) {
+			t.Errorf("expected fingerprint %s for %s, got %s", pgpFingerprint(entity), username, pgpFingerprint(key))
+		}
+	}
+
+	for _, tc := range []struct {
+		pgpKey      string
+		keyringFile string
+		expected    string
+	}{
+		{"keybase:alice", "", "set pgp_keyring"},
+		{"keybase:carol", keyringFile, "could not find the PGP key of Keybase user carol"},
+		{"keybase:", keyringFile, "got no username"},
+		{"keybase:alice", filepath.Join(t.TempDir(), "missing.asc"), "could not read PGP keyring"},
+	} {
+		_, err := parsePGPKey(tc.pgpKey, tc.keyringFile)
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("expected error %q for %s, got %v", tc.expected, tc.pgpKey, err)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &PipelineDefinitionResource{}
+
+func NewPipelineDefinitionResource() resource.Resource {
+	return &PipelineDefinitionResource{}
+}
+
+type PipelineDefinitionResource struct {
+	client *CircleciAPIClient
+}
+
+type PipelineDefinitionResourceModel struct {
+	Id                           types.String `tfsdk:"id"`
+	ProjectID                    types.String `tfsdk:"project_id"`
+	Name                         types.String `tfsdk:"name"`
+	Description                  types.String `tfsdk:"description"`
+	CreatedAt                    types.String `tfsdk:"created_at"`
+	ConfigSourceProvider         types.String `tfsdk:"config_source_provider"`
+	ConfigSourceRepoExternalID   types.String `tfsdk:"config_source_repo_external_id"`
+	ConfigSourceRepoFullName     types.String `tfsdk:"config_source_repo_full_name"`
+	ConfigSourceFilePath         types.String `tfsdk:"config_source_file_path"`
+	CheckoutSourceProvider       types.String `tfsdk:"checkout_source_provider"`
+	CheckoutSourceRepoExternalID types.String `tfsdk:"checkout_source_repo_external_id"`
+	CheckoutSourceRepoFullName   types.String `tfsdk:"checkout_source_repo_full_name"`
+}
+
+// sourceRepo is a repository of a pipeline definition or trigger, in the v2 API.
+type sourceRepo struct {
+	ExternalID string `json:"external_id,omitempty"`
+	FullName   string `json:"full_name,omitempty"`
+}
+
+type pipelineDefinitionConfigSource struct {
+	Provider string      `json:"provider,omitempty"`
+	Repo     *sourceRepo `json:"repo,omitempty"`
+	FilePath string      `json:"file_path,omitempty"`
+}
+
+type pipelineDefinitionCheckoutSource struct {
+	Provider string      `json:"provider,omitempty"`
+	Repo     *sourceRepo `json:"repo,omitempty"`
+}
+
+// pipelineDefinition is a pipeline definition of a project, in the v2 API.
+type pipelineDefinition struct {
+	ID             string                            `json:"id,omitempty"`
+	Name           string                            `json:"name,omitempty"`
+	Description    *string                           `json:"description,omitempty"`
+	CreatedAt      string                            `json:"created_at,omitempty"`
+	ConfigSource   *pipelineDefinitionConfigSource   `json:"config_source,omitempty"`
+	CheckoutSource *pipelineDefinitionCheckoutSource `json:"checkout_source,omitempty"`
+}
+
+func (m *PipelineDefinitionResourceModel) fromAPI(d pipelineDefinition) {
+	m.Id = types.StringValue(d.ID)
+	m.Name = types.StringValue(d.Name)
+	m.Description = types.StringValue("")
+	if d.Description != nil {
+		m.Description = types.StringValue(*d.Description)
+	}
+	m.CreatedAt = types.StringValue(d.CreatedAt)
+	if s := d.ConfigSource; s != nil {
+		m.ConfigSourceProvider = types.StringValue(s.Provider)
+		m.ConfigSourceFilePath = types.StringValue(s.FilePath)
+		if s.Repo != nil {
+			m.ConfigSourceRepoExternalID = types.StringValue(s.Repo.ExternalID)
+			m.ConfigSourceRepoFullName = types.StringValue(s.Repo.FullName)
+		}
+	}
+	if s := d.CheckoutSource; s != nil {
+		m.CheckoutSourceProvider = types.StringValue(s.Provider)
+		if s.Repo != nil {
+			m.CheckoutSourceRepoExternalID = types.StringValue(s.Repo.ExternalID)
+			m.CheckoutSourceRepoFullName = types.StringValue(s.Repo.FullName)
+		}
+	}
+}
+
+func (r *PipelineDefinitionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_definition"
+}
+
+func (r *PipelineDefinitionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a pipeline definition of a project (for GitLab or GitHub App organizations)",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Read-only unique identifier",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The unique ID of the project",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the pipeline definition",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the pipeline definition",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Date-time this pipeline definition was created",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"config_source_provider": schema.StringAttribute{
+				MarkdownDescription: "Provider of the repository hosting the config file (e.g., `github_app`)",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"config_source_repo_external_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the repository hosting the config file, as assigned by its provider (e.g., the GitHub repository ID)",
+				Required:            true,
+			},
+			"config_source_repo_full_name": schema.StringAttribute{
+				MarkdownDescription: "Full name of the repository hosting the config file",
+				Computed:            true,
+			},
+			"config_source_file_path": schema.StringAttribute{
+				MarkdownDescription: "Path to the config file in its repository (e.g., `.circleci/config.yml`)",
+				Required:            true,
+			},
+			"checkout_source_provider": schema.StringAttribute{
+				MarkdownDescription: "Provider of the repository checked out by the pipelines (e.g., `github_app`)",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"checkout_source_repo_external_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the repository checked out by the pipelines, as assigned by its provider",
+				Required:            true,
+			},
+			"checkout_source_repo_full_name": schema.StringAttribute{
+				MarkdownDescription: "Full name of the repository checked out by the pipelines",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *PipelineDefinitionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PipelineDefinitionResource) url(projectID, id string) string {
+	if id == "" {
+		return r.client.apiURL(fmt.Sprintf("/api/v2/projects/%s/pipeline-definitions", projectID))
+	}
+	return r.client.apiURL(fmt.Sprintf("/api/v2/projects/%s/pipeline-definitions/%s", projectID, id))
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *PipelineDefinitionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state PipelineDefinitionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.Id.ValueString()
+	var d pipelineDefinition
+	err := r.client.doJSON(ctx, http.MethodGet, r.url(state.ProjectID.ValueString(), id), nil, &d)
+	if isNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Pipeline definition %s no longer exists; removing from state.", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error reading Pipeline definition %s", id), fmt.Sprintf("%s", err))
+		return
+	}
+
+	state.fromAPI(d)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *PipelineDefinitionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan PipelineDefinitionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	description := plan.Description.ValueString()
+	body := pipelineDefinition{
+		Name:        plan.Name.ValueString(),
+		Description: &description,
+		ConfigSource: &pipelineDefinitionConfigSource{
+			Provider: plan.ConfigSourceProvider.ValueString(),
+			Repo:     &sourceRepo{ExternalID: plan.ConfigSourceRepoExternalID.ValueString()},
+			FilePath: plan.ConfigSourceFilePath.ValueString(),
+		},
+		CheckoutSource: &pipelineDefinitionCheckoutSource{
+			Provider: plan.CheckoutSourceProvider.ValueString(),
+			Repo:     &sourceRepo{ExternalID: plan.CheckoutSourceRepoExternalID.ValueString()},
+		},
+	}
+
+	var d pipelineDefinition
+	if err := r.client.doJSON(ctx, http.MethodPost, r.url(plan.ProjectID.ValueString(), ""), body, &d); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating pipeline definition",
+			fmt.Sprintf("Could not create pipeline definition, unexpected error: %s", err.Error()),
+		)
+		return
+	}
+
+	plan.fromAPI(d)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *PipelineDefinitionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan PipelineDefinitionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := plan.Id.ValueString()
+	description := plan.Description.ValueString()
+	body := pipelineDefinition{
+		Name:        plan.Name.ValueString(),
+		Description: &description,
+		ConfigSource: &pipelineDefinitionConfigSource{
+			Repo:     &sourceRepo{ExternalID: plan.ConfigSourceRepoExternalID.ValueString()},
+			FilePath: plan.ConfigSourceFilePath.ValueString(),
+		},
+		CheckoutSource: &pipelineDefinitionCheckoutSource{
+			Repo: &sourceRepo{ExternalID: plan.CheckoutSourceRepoExternalID.ValueString()},
+		},
+	}
+
+	var d pipelineDefinition
+	if err := r.client.doJSON(ctx, http.MethodPatch, r.url(plan.ProjectID.ValueString(), id), body, &d); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating pipeline definition",
+			fmt.Sprintf("Could not update pipeline definition %s, unexpected error: %s", id, err.Error()),
+		)
+		return
+	}
+
+	plan.fromAPI(d)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *PipelineDefinitionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PipelineDefinitionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.Id.ValueString()
+	err := r.client.doJSON(ctx, http.MethodDelete, r.url(state.ProjectID.ValueString(), id), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting pipeline definition",
+			fmt.Sprintf("Could not delete pipeline definition %s, unexpected error: %s", id, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *PipelineDefinitionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, id, ok := strings.Cut(req.ID, "/")
+	if !ok || projectID == "" || id == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_id/pipeline_definition_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
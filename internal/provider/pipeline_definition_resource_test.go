@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testAccRepoExternalID returns the ID of the GitHub repository of the standalone project,
+// as assigned by GitHub, or skips the test when it is not provided.
+func testAccRepoExternalID(t *testing.T) string {
+	id := os.Getenv("CIRCLECI_TEST_REPO_EXTERNAL_ID")
+	if id == "" {
+		t.Skip("CIRCLECI_TEST_REPO_EXTERNAL_ID must be set for pipeline definition acceptance tests")
+	}
+	return id
+}
+
+func TestPipelineDefinitionFromAPI(t *testing.T) {
+	payload := `{
+		"id": "2338d0ae-5541-4bbf-88a2-55e9f7281f3c",
+		"name": "build",
+		"description": "",
+		"created_at": "2024-08-15T12:00:00Z",
+		"config_source": {"provider": "github_app", "repo": {"full_name": "acme/foobar", "external_id": "123"}, "file_path": ".circleci/config.yml"},
+		"checkout_source": {"provider": "github_app", "repo": {"full_name": "acme/foobar", "external_id": "123"}}
+	}`
+	var d pipelineDefinition
+	if err := json.Unmarshal([]byte(payload), &d); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var m PipelineDefinitionResourceModel
+	m.fromAPI(d)
+
+	want := map[string]string{
+		"id":                               "2338d0ae-5541-4bbf-88a2-55e9f7281f3c",
+		"name":                             "build",
+		"description":                      "",
+		"config_source_provider":           "github_app",
+		"config_source_repo_external_id":   "123",
+		"config_source_repo_full_name":     "acme/foobar",
+		"config_source_file_path":          ".circleci/config.yml",
+		"checkout_source_repo_external_id": "123",
+	}
+	got := map[string]string{
+		"id":                               m.Id.ValueString(),
+		"name":                             m.Name.ValueString(),
+		"description":                      m.Description.ValueString(),
+		"config_source_provider":           m.ConfigSourceProvider.ValueString(),
+		"config_source_repo_external_id":   m.ConfigSourceRepoExternalID.ValueString(),
+		"config_source_repo_full_name":     m.ConfigSourceRepoFullName.ValueString(),
+		"config_source_file_path":          m.ConfigSourceFilePath.ValueString(),
+		"checkout_source_repo_external_id": m.CheckoutSourceRepoExternalID.ValueString(),
+	}
+	for name, v := range want {
+		if got[name] != v {
+			t.Errorf("%s: expected %q, got %q", name, v, got[name])
+		}
+	}
+}
+
+func TestAccPipelineDefinitionResource(t *testing.T) {
+	externalID := testAccRepoExternalID(t)
+	config := func(filePath string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "circleci_pipeline_definition" "pd1" {
+	project_id                       = "%s"
+	name                             = "tf-acceptance-test"
+	config_source_provider           = "github_app"
+	config_source_repo_external_id   = "%s"
+	config_source_file_path          = "%s"
+	checkout_source_provider         = "github_app"
+	checkout_source_repo_external_id = "%s"
+}
+`, standaloneProjectId, externalID, filePath, externalID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config(".circleci/config.yml"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("circleci_pipeline_definition.pd1", "id"),
+					resource.TestCheckResourceAttrSet("circleci_pipeline_definition.pd1", "created_at"),
+					resource.TestCheckResourceAttrSet("circleci_pipeline_definition.pd1", "config_source_repo_full_name"),
+					resource.TestCheckResourceAttr("circleci_pipeline_definition.pd1", "config_source_file_path", ".circleci/config.yml"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName: "circleci_pipeline_definition.pd1",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["circleci_pipeline_definition.pd1"]
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["project_id"], rs.Primary.ID), nil
+				},
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: config(".circleci/other.yml"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_pipeline_definition.pd1", "config_source_file_path", ".circleci/other.yml"),
+				),
+			},
+		},
+	})
+}
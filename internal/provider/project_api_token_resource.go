@@ -0,0 +1,345 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ProjectAPITokenResource{}
+var _ resource.ResourceWithModifyPlan = &ProjectAPITokenResource{}
+
+func NewProjectAPITokenResource() resource.Resource {
+	return &ProjectAPITokenResource{}
+}
+
+type ProjectAPITokenResource struct {
+	client *CircleciAPIClient
+}
+
+type ProjectAPITokenResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	ProjectSlug types.String `tfsdk:"project_slug"`
+	Label       types.String `tfsdk:"label"`
+	Scope       types.String `tfsdk:"scope"`
+	Token       types.String `tfsdk:"token"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	Keepers     types.Map    `tfsdk:"keepers"`
+
+	PGPKey         types.String `tfsdk:"pgp_key"`
+	EncryptedToken types.String `tfsdk:"encrypted_token"`
+	KeyFingerprint types.String `tfsdk:"key_fingerprint"`
+}
+
+var vProjectTokenScopes = []string{
+	"status",
+	"build-artifacts",
+	"all",
+}
+
+// projectAPIToken is a project API token, in the v1.1 API.
+type projectAPIToken struct {
+	ID    string `json:"id,omitempty"`
+	Label string `json:"label"`
+	Scope string `json:"scope"`
+	Time  string `json:"time,omitempty"`
+	Token string `json:"token,omitempty"`
+}
+
+func (r *ProjectAPITokenResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_api_token"
+}
+
+func (r *ProjectAPITokenResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a project API token",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Read-only unique identifier",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_slug": schema.StringAttribute{
+				MarkdownDescription: "The project-slug for the API token",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"label": schema.StringAttribute{
+				MarkdownDescription: "The label of the API token",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "The scope of the API token. This may be either `status`, `build-artifacts` or `all`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(vProjectTokenScopes...),
+				},
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The API token. CircleCI only returns it once, when created. This is not set if `pgp_key` is set.",
+				Computed:            true,
+				Sensitive:           true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Date-time this API token was created",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"pgp_key": schema.StringAttribute{
+				MarkdownDescription: "A PGP public key to encrypt the API token with, either base64-encoded (e.g., via `gpg --export <key-id> | base64`), armored, or as `keybase:<username>`, found in the provider's `pgp_keyring`. If set, only `encrypted_token` and `key_fingerprint` are set, and `token` is not. Required when the provider's `store_secrets_in_state` is false.",
+				Optional:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"encrypted_token": schema.StringAttribute{
+				MarkdownDescription: "The API token, encrypted with `pgp_key` and base64-encoded. Decrypt it with e.g., `base64 --decode | gpg --decrypt`.",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_fingerprint": schema.StringAttribute{
+				MarkdownDescription: "The fingerprint of the PGP key used to encrypt the API token.",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, rotates the API token (i.e., replaces it).",
+				ElementType:         types.StringType,
+				Optional:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *ProjectAPITokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ProjectAPITokenResource) url(projectSlug string) string {
+	return r.client.apiURL(fmt.Sprintf("/api/v1.1/project/%s/token", projectSlug))
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ProjectAPITokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state ProjectAPITokenResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.Id.ValueString()
+	projectSlug := state.ProjectSlug.ValueString()
+
+	var tokens []projectAPIToken
+	if err := r.client.doJSON(ctx, http.MethodGet, r.url(projectSlug), nil, &tokens); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error reading Project(%s) API token %s", projectSlug, id), fmt.Sprintf("%s", err))
+		return
+	}
+
+	var found *projectAPIToken
+	for i, t := range tokens {
+		if t.ID == id {
+			found = &tokens[i]
+			break
+		}
+	}
+	if found == nil {
+		tflog.Warn(ctx, fmt.Sprintf("API token no longer found: %s", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Label = types.StringValue(found.Label)
+	state.Scope = types.StringValue(found.Scope)
+	state.CreatedAt = types.StringValue(found.Time)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan validates the PGP key, so that the token is never created without a way to encrypt it,
+// nor kept as-is in state when store_secrets_in_state is false.
+func (r *ProjectAPITokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planPGPKey(ctx, r.client, req, resp, "API token")
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ProjectAPITokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan ProjectAPITokenResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// parse the PGP key first (as validated when planned, unless unknown then),
+	// so that the token is never created without a way to encrypt it.
+	var entity *openpgp.Entity
+	if plan.PGPKey.ValueString() != "" {
+		var err error
+		entity, err = parsePGPKey(plan.PGPKey.ValueString(), r.client.pgpKeyringFile())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("pgp_key"),
+				"Invalid PGP key",
+				fmt.Sprintf("Could not parse PGP key, unexpected error: %s", err.Error()),
+			)
+			return
+		}
+	}
+
+	projectSlug := plan.ProjectSlug.ValueString()
+	body := projectAPIToken{
+		Label: plan.Label.ValueString(),
+		Scope: plan.Scope.ValueString(),
+	}
+
+	var t projectAPIToken
+	if err := r.client.doJSON(ctx, http.MethodPost, r.url(projectSlug), body, &t); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating project API token",
+			fmt.Sprintf("Could not create project API token, unexpected error: %s", err.Error()),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(t.ID)
+	if entity != nil {
+		encrypted, err := encryptWithPGPKey(entity, t.Token)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error encrypting project API token",
+				fmt.Sprintf("Could not encrypt project API token %s, unexpected error: %s", t.ID, err.Error()),
+			)
+			// the token is of no use without being stored, so it is not left behind
+			url := fmt.Sprintf("%s/%s", r.url(projectSlug), t.ID)
+			if err := r.client.doJSON(ctx, http.MethodDelete, url, nil, nil); err != nil {
+				resp.Diagnostics.AddError(
+					"Error deleting project API token",
+					fmt.Sprintf("Could not delete unencrypted project(%s) API token %s, unexpected error: %s. Please delete it manually.", projectSlug, t.ID, err.Error()),
+				)
+			}
+			return
+		}
+		plan.Token = types.StringNull()
+		plan.EncryptedToken = types.StringValue(encrypted)
+		plan.KeyFingerprint = types.StringValue(pgpFingerprint(entity))
+	} else {
+		// generated tokens are of no use hashed, so they are kept as-is in state;
+		// pgp_key is required instead when store_secrets_in_state is false (see ModifyPlan).
+		plan.Token = types.StringValue(t.Token)
+		plan.EncryptedToken = types.StringNull()
+		plan.KeyFingerprint = types.StringNull()
+	}
+	plan.CreatedAt = types.StringValue(t.Time)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ProjectAPITokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// not implemented; requires a replacement
+}
+
+func (r *ProjectAPITokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state ProjectAPITokenResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.Id.ValueString()
+	projectSlug := state.ProjectSlug.ValueString()
+	url := fmt.Sprintf("%s/%s", r.url(projectSlug), id)
+
+	err := r.client.doJSON(ctx, http.MethodDelete, url, nil, nil)
+	if isNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("API token no longer found: %s", id))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting project API token",
+			fmt.Sprintf("Could not delete project(%s) API token %s, unexpected error: %s", projectSlug, id, err.Error()),
+		)
+		return
+	}
+}
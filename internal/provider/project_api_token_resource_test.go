@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccProjectAPITokenResource(t *testing.T) {
+	config := func(rotation string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "circleci_project_api_token" "t1" {
+	project_slug = "%s"
+	label        = "tf-acceptance-test"
+	scope        = "status"
+
+	keepers = {
+		rotation = "%s"
+	}
+}
+`, projectSlug, rotation)
+	}
+
+	var id string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config("1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_project_api_token.t1", "label", "tf-acceptance-test"),
+					resource.TestCheckResourceAttr("circleci_project_api_token.t1", "scope", "status"),
+					resource.TestCheckResourceAttrSet("circleci_project_api_token.t1", "token"),
+					resource.TestCheckResourceAttrSet("circleci_project_api_token.t1", "created_at"),
+					resource.TestCheckResourceAttrWith("circleci_project_api_token.t1", "id", func(value string) error {
+						id = value
+						return nil
+					}),
+				),
+			},
+			// Rotation testing
+			{
+				Config: config("2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("circleci_project_api_token.t1", "token"),
+					resource.TestCheckResourceAttrWith("circleci_project_api_token.t1", "id", func(value string) error {
+						if value == id {
+							return fmt.Errorf("expected API token to be rotated, got same ID %s", id)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/kelvintaywl/circleci-go-sdk/client/project"
+	"github.com/kelvintaywl/circleci-go-sdk/models"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
@@ -35,6 +36,18 @@ type ProjectDataSourceModel struct {
 	VcsURL           types.String `tfsdk:"vcs_url"`
 }
 
+// fromAPI sets the project information, except the slug.
+func (m *ProjectDataSourceModel) fromAPI(pj *models.ProjectInfo) {
+	m.Id = types.StringValue(pj.ID.String())
+	m.Name = types.StringValue(pj.Name)
+	m.OrganizationName = types.StringValue(pj.OrganizationName)
+	m.OrganizationSlug = types.StringValue(pj.OrganizationSlug)
+	m.OrganizationId = types.StringValue(pj.OrganizationID.String())
+	m.VcsProvider = types.StringValue(pj.VcsInfo.Provider)
+	m.VcsDefaultBranch = types.StringValue(pj.VcsInfo.DefaultBranch)
+	m.VcsURL = types.StringValue(pj.VcsInfo.VcsURL)
+}
+
 func (d *ProjectDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_project"
 }
@@ -124,15 +137,7 @@ func (d *ProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	pj := res.GetPayload()
-	data.Id = types.StringValue(pj.ID.String())
-	data.Name = types.StringValue(pj.Name)
-	data.OrganizationName = types.StringValue(pj.OrganizationName)
-	data.OrganizationSlug = types.StringValue(pj.OrganizationSlug)
-	data.OrganizationId = types.StringValue(pj.OrganizationID.String())
-	data.VcsProvider = types.StringValue(pj.VcsInfo.Provider)
-	data.VcsDefaultBranch = types.StringValue(pj.VcsInfo.DefaultBranch)
-	data.VcsURL = types.StringValue(pj.VcsInfo.VcsURL)
+	data.fromAPI(res.GetPayload())
 
 	// Save data into Terraform state
 	diags := resp.State.Set(ctx, &data)
@@ -0,0 +1,354 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kelvintaywl/circleci-go-sdk/client/project"
+	"github.com/kelvintaywl/circleci-go-sdk/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ProjectEnvVarsResource{}
+var _ resource.ResourceWithModifyPlan = &ProjectEnvVarsResource{}
+
+func NewProjectEnvVarsResource() resource.Resource {
+	return &ProjectEnvVarsResource{}
+}
+
+type ProjectEnvVarsResource struct {
+	client *CircleciAPIClient
+}
+
+type ProjectEnvVarsResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	ProjectSlug    types.String `tfsdk:"project_slug"`
+	Variables      types.Map    `tfsdk:"variables"`
+	VariablesWO    types.Map    `tfsdk:"variables_wo"`
+	VariablesHash  types.Map    `tfsdk:"variables_hash"`
+	Exclusive      types.Bool   `tfsdk:"exclusive"`
+	UnmanagedNames types.Set    `tfsdk:"unmanaged_names"`
+}
+
+func (r *ProjectEnvVarsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_env_vars"
+}
+
+func (r *ProjectEnvVarsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a set of project environment variables",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Read-only unique identifier: uses project_slug",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_slug": schema.StringAttribute{
+				MarkdownDescription: "The project-slug for the environment variables",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"variables": schema.MapAttribute{
+				MarkdownDescription: "The environment variables, as a map of name to value",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"variables_wo":   envVarsWriteOnlyAttribute("The environment variables, as a map of name to value."),
+			"variables_hash": envVarsHashAttribute(),
+			"exclusive": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete any environment variable of the project not found in `variables` (default: false)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"unmanaged_names": schema.SetAttribute{
+				MarkdownDescription: "Names of the project environment variables not found in `variables`. These are deleted when `exclusive` is true",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *ProjectEnvVarsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// listProjectEnvVarNames pages through all the environment variable names of a project.
+func listProjectEnvVarNames(ctx context.Context, client *CircleciAPIClient, projectSlug string) ([]string, error) {
+	var names []string
+	nextToken := ""
+
+	for {
+		param := project.NewListProjectEnvVarsParamsWithContext(ctx).WithDefaults()
+		param = param.WithProjectSlug(projectSlug)
+		if nextToken != "" {
+			param = param.WithPageToken(nextToken)
+		}
+
+		res, err := client.Client.Project.ListProjectEnvVars(param, client.Auth)
+		if err != nil {
+			return nil, err
+		}
+
+		info := res.GetPayload()
+		for _, ev := range info.Items {
+			names = append(names, *ev.Name)
+		}
+
+		nextToken = info.NextPageToken
+		if nextToken == "" {
+			return names, nil
+		}
+	}
+}
+
+// envVarSet returns the env vars of the project, managed as a whole.
+func (r *ProjectEnvVarsResource) envVarSet(projectSlug string) *envVarSet {
+	return &envVarSet{
+		owner: fmt.Sprintf("Project(%s)", projectSlug),
+		list: func(ctx context.Context) ([]string, error) {
+			return listProjectEnvVarNames(ctx, r.client, projectSlug)
+		},
+		upsert: func(ctx context.Context, name, value string) (string, error) {
+			param := project.NewAddProjectEnvVarParamsWithContext(ctx).WithDefaults()
+			param = param.WithProjectSlug(projectSlug)
+
+			body := models.ProjectEnvVarPayload{
+				Name:  &name,
+				Value: &value,
+			}
+
+			param = param.WithBody(&body)
+
+			unlock := r.client.lockProject(projectSlug)
+			defer unlock()
+
+			// project env vars do not have an updated date and time
+			_, err := r.client.Client.Project.AddProjectEnvVar(param, r.client.Auth)
+			return "", err
+		},
+		delete: func(ctx context.Context, name string) error {
+			param := project.NewDeleteProjectEnvVarParamsWithContext(ctx).WithDefaults()
+			param = param.WithProjectSlug(projectSlug).WithName(name)
+
+			unlock := r.client.lockProject(projectSlug)
+			defer unlock()
+
+			_, err := r.client.Client.Project.DeleteProjectEnvVar(param, r.client.Auth)
+			return err
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ProjectEnvVarsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state ProjectEnvVarsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectSlug := state.ProjectSlug.ValueString()
+	names, err := listProjectEnvVarNames(ctx, r.client, projectSlug)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error reading Project(%s) env vars", projectSlug), fmt.Sprintf("%s", err))
+		return
+	}
+
+	prior, diags := envVarsFromState(ctx, state.Variables, state.VariablesHash)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := map[string]bool{}
+	for _, name := range names {
+		found[name] = true
+	}
+	// CircleCI returns the values masked (except the last 4 characters)
+	// Hence, we only drop the variables that no longer exist, so they are written again.
+	for name := range prior.names() {
+		if !found[name] {
+			tflog.Warn(ctx, fmt.Sprintf("Project(%s) env var no longer found: %s", projectSlug, name))
+			prior.drop(name)
+		}
+	}
+
+	state.Id = types.StringValue(projectSlug)
+	state.Variables, state.VariablesHash, diags = prior.toState(ctx)
+	resp.Diagnostics.Append(diags...)
+	state.UnmanagedNames, diags = types.SetValueFrom(ctx, types.StringType, unmanagedNames(names, prior.names()))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan keeps the hashed values (see variables_wo) in the plan while they match the configuration,
+// and reports the unmanaged env vars, which are either kept or deleted based on exclusive.
+func (r *ProjectEnvVarsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var projectSlug types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("project_slug"), &projectSlug)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planEnvVars(ctx, r.client, req, resp, fmt.Sprintf("Project(%s)", projectSlug.ValueString()))
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ProjectEnvVarsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan ProjectEnvVarsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, writeOnly, diags := configEnvVars(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectSlug := plan.ProjectSlug.ValueString()
+	_, unmanaged, err := r.envVarSet(projectSlug).sync(ctx, planned, envVarsInState{}, nil, plan.Exclusive.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating project env vars",
+			fmt.Sprintf("Could not create project(%s) env vars, unexpected error: %s", projectSlug, err.Error()),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(projectSlug)
+	plan.VariablesHash, diags = appliedEnvVarHashes(ctx, planned, writeOnly, envVarsInState{}, plan.VariablesHash)
+	resp.Diagnostics.Append(diags...)
+	plan.UnmanagedNames, diags = appliedUnmanagedNames(ctx, plan.UnmanagedNames, unmanaged)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ProjectEnvVarsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state ProjectEnvVarsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, writeOnly, diags := configEnvVars(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	prior, diags := envVarsFromState(ctx, state.Variables, state.VariablesHash)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectSlug := plan.ProjectSlug.ValueString()
+	_, unmanaged, err := r.envVarSet(projectSlug).sync(ctx, planned, prior, nil, plan.Exclusive.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating project env vars",
+			fmt.Sprintf("Could not update project(%s) env vars, unexpected error: %s", projectSlug, err.Error()),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(projectSlug)
+	plan.VariablesHash, diags = appliedEnvVarHashes(ctx, planned, writeOnly, prior, plan.VariablesHash)
+	resp.Diagnostics.Append(diags...)
+	plan.UnmanagedNames, diags = appliedUnmanagedNames(ctx, plan.UnmanagedNames, unmanaged)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ProjectEnvVarsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state ProjectEnvVarsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior, diags := envVarsFromState(ctx, state.Variables, state.VariablesHash)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the managed env vars are deleted
+	projectSlug := state.ProjectSlug.ValueString()
+	if err := r.envVarSet(projectSlug).deleteAll(ctx, prior); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting project env vars",
+			fmt.Sprintf("Could not delete project(%s) env vars, unexpected error: %s", projectSlug, err.Error()),
+		)
+		return
+	}
+}
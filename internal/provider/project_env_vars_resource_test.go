@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccProjectEnvVarsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_project_env_vars" "envs" {
+	project_slug = "%s"
+	variables = {
+		LOREM = "random1234"
+		IPSUM = "Lorem Ipsum"
+	}
+}
+`, projectSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_project_env_vars.envs", "project_slug", projectSlug),
+					resource.TestCheckResourceAttr("circleci_project_env_vars.envs", "id", projectSlug),
+					resource.TestCheckResourceAttr("circleci_project_env_vars.envs", "exclusive", "false"),
+					resource.TestCheckResourceAttr("circleci_project_env_vars.envs", "variables.%", "2"),
+					resource.TestCheckResourceAttr("circleci_project_env_vars.envs", "variables.LOREM", "random1234"),
+					resource.TestCheckResourceAttr("circleci_project_env_vars.envs", "variables.IPSUM", "Lorem Ipsum"),
+					resource.TestCheckResourceAttrSet("circleci_project_env_vars.envs", "unmanaged_names.#"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_project_env_vars" "envs" {
+	project_slug = "%s"
+	variables = {
+		LOREM = "changed"
+		DOLOR = "sit amet"
+	}
+}
+`, projectSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_project_env_vars.envs", "variables.%", "2"),
+					resource.TestCheckResourceAttr("circleci_project_env_vars.envs", "variables.LOREM", "changed"),
+					resource.TestCheckResourceAttr("circleci_project_env_vars.envs", "variables.DOLOR", "sit amet"),
+					resource.TestCheckNoResourceAttr("circleci_project_env_vars.envs", "variables.IPSUM"),
+				),
+			},
+			// Create and Read testing for standalone, as exclusive.
+			// exclusive deletes the other env vars of the project, so use a project of its own.
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_project" "exclusive" {
+	organization_id = "%s"
+	name            = "tf-acceptance-test-exclusive-env-vars"
+}
+
+resource "circleci_project_env_vars" "standalone" {
+	project_slug = circleci_project.exclusive.slug
+	variables = {
+		IPSUM = "standalone123"
+	}
+	exclusive = true
+}
+`, standaloneOrgId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("circleci_project_env_vars.standalone", "project_slug", "circleci_project.exclusive", "slug"),
+					resource.TestCheckResourceAttr("circleci_project_env_vars.standalone", "exclusive", "true"),
+					resource.TestCheckResourceAttr("circleci_project_env_vars.standalone", "variables.IPSUM", "standalone123"),
+					resource.TestCheckResourceAttr("circleci_project_env_vars.standalone", "unmanaged_names.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kelvintaywl/circleci-go-sdk/client/oidc"
+	"github.com/kelvintaywl/circleci-go-sdk/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ProjectOIDCClaimsResource{}
+
+func NewProjectOIDCClaimsResource() resource.Resource {
+	return &ProjectOIDCClaimsResource{}
+}
+
+type ProjectOIDCClaimsResource struct {
+	client *CircleciAPIClient
+}
+
+type ProjectOIDCClaimsResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	OrgID             types.String `tfsdk:"org_id"`
+	ProjectID         types.String `tfsdk:"project_id"`
+	Audience          types.Set    `tfsdk:"audience"`
+	TTL               types.String `tfsdk:"ttl"`
+	AudienceUpdatedAt types.String `tfsdk:"audience_updated_at"`
+	TTLUpdatedAt      types.String `tfsdk:"ttl_updated_at"`
+}
+
+func (r *ProjectOIDCClaimsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_oidc_claims"
+}
+
+func (r *ProjectOIDCClaimsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := oidcClaimsAttributes("project")
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "Read-only unique identifier: project ID",
+		Computed:            true,
+		// unchanged even during updates
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["org_id"] = schema.StringAttribute{
+		MarkdownDescription: "The unique ID of the organization of the project",
+		Required:            true,
+		// if modifed, this requires a replacement instead.
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["project_id"] = schema.StringAttribute{
+		MarkdownDescription: "The unique ID of the project",
+		Required:            true,
+		// if modifed, this requires a replacement instead.
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the OIDC custom claims of a project, overriding those of its organization",
+		Attributes:          attributes,
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *ProjectOIDCClaimsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ProjectOIDCClaimsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state ProjectOIDCClaimsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := state.ProjectID.ValueString()
+	param := oidc.NewGetProjectLevelClaimsParamsWithContext(ctx).WithDefaults()
+	param = param.WithOrgID(strfmt.UUID(state.OrgID.ValueString())).WithProjectID(strfmt.UUID(projectID))
+
+	res, err := r.client.Client.Oidc.GetProjectLevelClaims(param, r.client.Auth)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error reading Project(%s) OIDC claims", projectID), fmt.Sprintf("%s", err))
+		return
+	}
+
+	diags = refreshOIDCClaims(ctx, res.GetPayload().OrgLevelClaimsInfo, &state.Audience, &state.TTL, &state.AudienceUpdatedAt, &state.TTLUpdatedAt)
+	resp.Diagnostics.Append(diags...)
+	state.Id = state.ProjectID
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// write sets the planned claims, and restores the defaults of the claims no longer planned.
+func (r *ProjectOIDCClaimsResource) write(ctx context.Context, plan *ProjectOIDCClaimsResourceModel, state *ProjectOIDCClaimsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	orgID := strfmt.UUID(plan.OrgID.ValueString())
+	projectID := strfmt.UUID(plan.ProjectID.ValueString())
+
+	if state != nil {
+		if claims := removedOIDCClaims(plan.Audience, state.Audience, plan.TTL, state.TTL); claims != "" {
+			param := oidc.NewDeleteProjectLevelClaimsParamsWithContext(ctx).WithDefaults()
+			param = param.WithOrgID(orgID).WithProjectID(projectID).WithClaims(claims)
+			if _, err := r.client.Client.Oidc.DeleteProjectLevelClaims(param, r.client.Auth); err != nil {
+				diags.AddError(
+					"Error updating project OIDC claims",
+					fmt.Sprintf("Could not restore project OIDC claims (%s), unexpected error: %s", claims, err.Error()),
+				)
+				return diags
+			}
+		}
+	}
+
+	body, d := newOIDCClaimsPayload(ctx, plan.Audience, plan.TTL)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	var info models.OrgLevelClaimsInfo
+	url := r.client.apiURL(fmt.Sprintf("/api/v2/org/%s/project/%s/oidc-custom-claims", orgID, projectID))
+	if err := r.client.doJSON(ctx, http.MethodPatch, url, body, &info); err != nil {
+		diags.AddError(
+			"Error updating project OIDC claims",
+			fmt.Sprintf("Could not update project OIDC claims, unexpected error: %s", err.Error()),
+		)
+		return diags
+	}
+
+	diags.Append(refreshOIDCClaims(ctx, info, &plan.Audience, &plan.TTL, &plan.AudienceUpdatedAt, &plan.TTLUpdatedAt)...)
+	plan.Id = plan.ProjectID
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ProjectOIDCClaimsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan ProjectOIDCClaimsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.write(ctx, &plan, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ProjectOIDCClaimsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ProjectOIDCClaimsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.write(ctx, &plan, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ProjectOIDCClaimsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ProjectOIDCClaimsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// restores the defaults
+	projectID := state.ProjectID.ValueString()
+	param := oidc.NewDeleteProjectLevelClaimsParamsWithContext(ctx).WithDefaults()
+	param = param.WithOrgID(strfmt.UUID(state.OrgID.ValueString())).WithProjectID(strfmt.UUID(projectID)).WithClaims("audience,ttl")
+
+	if _, err := r.client.Client.Oidc.DeleteProjectLevelClaims(param, r.client.Auth); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting project OIDC claims",
+			fmt.Sprintf("Could not delete project(%s) OIDC claims, unexpected error: %s", projectID, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *ProjectOIDCClaimsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgID, projectID, ok := strings.Cut(req.ID, "/")
+	if !ok || orgID == "" || projectID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: org_id/project_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), orgID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), projectID)...)
+}
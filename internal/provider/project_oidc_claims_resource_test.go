@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccProjectOIDCClaimsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_project_oidc_claims" "c1" {
+	org_id     = "%s"
+	project_id = "%s"
+	audience   = ["sts.amazonaws.com", "https://iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/circleci/providers/circleci"]
+}
+`, orgId, projectId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_project_oidc_claims.c1", "id", projectId),
+					resource.TestCheckResourceAttr("circleci_project_oidc_claims.c1", "audience.#", "2"),
+					resource.TestCheckTypeSetElemAttr("circleci_project_oidc_claims.c1", "audience.*", "sts.amazonaws.com"),
+					resource.TestCheckNoResourceAttr("circleci_project_oidc_claims.c1", "ttl"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "circleci_project_oidc_claims.c1",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s", orgId, projectId),
+				ImportStateVerify: true,
+				// the project inherits the TTL of its organization, unless set
+				ImportStateVerifyIgnore: []string{"ttl"},
+			},
+		},
+	})
+}
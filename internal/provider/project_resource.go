@@ -4,20 +4,27 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/kelvintaywl/circleci-go-sdk/client/project"
+	"github.com/kelvintaywl/circleci-go-sdk/models"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &ProjectResource{}
+var _ resource.ResourceWithImportState = &ProjectResource{}
 
 func NewProjectResource() resource.Resource {
 	return &ProjectResource{}
@@ -27,16 +34,21 @@ type ProjectResource struct {
 	client *CircleciAPIClient
 }
 
+// projectCreatedKey is the private state key marking projects created (rather than followed) by this provider,
+// or imported by organization ID and name.
+const projectCreatedKey = "created"
+
 type ProjectResourceModel struct {
-	Id               types.String `tfsdk:"id"`
-	Slug             types.String `tfsdk:"slug"`
-	Name             types.String `tfsdk:"name"`
-	OrganizationName types.String `tfsdk:"organization_name"`
-	OrganizationSlug types.String `tfsdk:"organization_slug"`
-	OrganizationId   types.String `tfsdk:"organization_id"`
-	VcsProvider      types.String `tfsdk:"vcs_provider"`
-	VcsDefaultBranch types.String `tfsdk:"vcs_default_branch"`
-	VcsURL           types.String `tfsdk:"vcs_url"`
+	Id                types.String `tfsdk:"id"`
+	Slug              types.String `tfsdk:"slug"`
+	Name              types.String `tfsdk:"name"`
+	OrganizationName  types.String `tfsdk:"organization_name"`
+	OrganizationSlug  types.String `tfsdk:"organization_slug"`
+	OrganizationId    types.String `tfsdk:"organization_id"`
+	VcsProvider       types.String `tfsdk:"vcs_provider"`
+	VcsDefaultBranch  types.String `tfsdk:"vcs_default_branch"`
+	VcsURL            types.String `tfsdk:"vcs_url"`
+	UnfollowOnDestroy types.Bool   `tfsdk:"unfollow_on_destroy"`
 }
 
 func (r *ProjectResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -56,12 +68,30 @@ func (r *ProjectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"slug": schema.StringAttribute{
-				MarkdownDescription: "Project slug in the form `vcs-slug/org-name/repo-name`. The / characters may be URL-escaped.",
-				Required:            true,
+				MarkdownDescription: "Project slug in the form `vcs-slug/org-name/repo-name`. The / characters may be URL-escaped. Set this to follow an existing project. Conflicts with `name`.",
+				Optional:            true,
+				Computed:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Name of the project",
+				MarkdownDescription: "Name of the project. Set this, along with `organization_id`, to create a new project (e.g., for GitLab or GitHub App organizations).",
+				Optional:            true,
 				Computed:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("organization_id")),
+				},
 			},
 			"organization_name": schema.StringAttribute{
 				MarkdownDescription: "The name of the organization the project belongs to",
@@ -72,8 +102,17 @@ func (r *ProjectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed:            true,
 			},
 			"organization_id": schema.StringAttribute{
-				MarkdownDescription: "The id of the organization the project belongs to",
+				MarkdownDescription: "The id of the organization the project belongs to. Required when creating a new project by `name`.",
+				Optional:            true,
 				Computed:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("name")),
+				},
 			},
 			"vcs_url": schema.StringAttribute{
 				MarkdownDescription: "URL to the repository hosting the project's code",
@@ -87,6 +126,12 @@ func (r *ProjectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				MarkdownDescription: "Default branch of this project",
 				Computed:            true,
 			},
+			"unfollow_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to unfollow the project when destroyed, so that CircleCI stops building it. Defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -160,14 +205,34 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	projectSlug := plan.Slug.ValueString()
-	url := fmt.Sprintf("https://%s/api/v1.1/project/%s/follow", r.client.Hostname, projectSlug)
-	request, err := http.NewRequest(http.MethodPost, url, nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Encountered error setting up API call", fmt.Sprintf("%s", err))
-	}
-	res, err := r.client.V1Client.Do(request)
-	if err != nil || res.StatusCode != 200 {
-		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error following project (%s)", projectSlug), fmt.Sprintf("%s", err))
+	if plan.Slug.IsNull() || plan.Slug.IsUnknown() {
+		// create a new project in the organization
+		orgId := plan.OrganizationId.ValueString()
+		url := r.client.apiURL(fmt.Sprintf("/api/v2/organization/%s/project", orgId))
+		body := map[string]string{"name": plan.Name.ValueString()}
+		var created struct {
+			Slug string `json:"slug"`
+		}
+		if err := r.client.doJSON(ctx, http.MethodPost, url, body, &created); err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating project",
+				fmt.Sprintf("Could not create project in organization (%s), unexpected error: %s", orgId, err.Error()),
+			)
+			return
+		}
+		projectSlug = created.Slug
+
+		diags = resp.Private.SetKey(ctx, projectCreatedKey, []byte("true"))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		url := r.client.apiURL(fmt.Sprintf("/api/v1.1/project/%s/follow", projectSlug))
+		if err := r.client.doJSON(ctx, http.MethodPost, url, nil, nil); err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Encountered error following project (%s)", projectSlug), fmt.Sprintf("%s", err))
+			return
+		}
 	}
 
 	// read
@@ -182,6 +247,7 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 
 	pj := readRes.GetPayload()
 	plan.Id = types.StringValue(pj.ID.String())
+	plan.Slug = types.StringValue(projectSlug)
 	plan.Name = types.StringValue(pj.Name)
 	plan.OrganizationName = types.StringValue(pj.OrganizationName)
 	plan.OrganizationSlug = types.StringValue(pj.OrganizationSlug)
@@ -198,11 +264,110 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 }
 
 func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// not implemented; not possible to update project
-	tflog.Warn(ctx, "Project cannot be updated via this provider.")
+	var plan, state ProjectResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the provider-side settings can be updated;
+	// other changes require a replacement
+	plan.OrganizationName = state.OrganizationName
+	plan.OrganizationSlug = state.OrganizationSlug
+	plan.VcsProvider = state.VcsProvider
+	plan.VcsDefaultBranch = state.VcsDefaultBranch
+	plan.VcsURL = state.VcsURL
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// not implemented; not possible to delete project
-	tflog.Warn(ctx, "Project cannot be deleted via this provider.")
+	var state ProjectResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectSlug := state.Slug.ValueString()
+
+	created, diags := req.Private.GetKey(ctx, projectCreatedKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if created != nil {
+		url := r.client.apiURL(fmt.Sprintf("/api/v2/project/%s", projectSlug))
+		if err := r.client.doJSON(ctx, http.MethodDelete, url, nil, nil); err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Error deleting project",
+				fmt.Sprintf("Could not delete project (%s), unexpected error: %s", projectSlug, err.Error()),
+			)
+		}
+		return
+	}
+
+	if !state.UnfollowOnDestroy.ValueBool() {
+		// not implemented; not possible to delete project
+		tflog.Warn(ctx, "Project cannot be deleted via this provider.")
+		return
+	}
+
+	url := r.client.apiURL(fmt.Sprintf("/api/v1.1/project/%s/unfollow", projectSlug))
+	if err := r.client.doJSON(ctx, http.MethodPost, url, nil, nil); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error unfollowing project (%s)", projectSlug), fmt.Sprintf("%s", err))
+		return
+	}
+}
+
+func (r *ProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// project slug, for followed projects
+	// or organization ID, name, for projects created by this provider (i.e., deleted on destroy)
+	orgId, name, created := strings.Cut(req.ID, ",")
+	if !created {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("slug"), req.ID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("unfollow_on_destroy"), false)...)
+		return
+	}
+
+	if orgId == "" || name == "" || strings.Contains(name, ",") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: slug or organization_id,name. Got: %q", req.ID),
+		)
+		return
+	}
+
+	projects, err := listOrgProjects(ctx, r.client, orgId)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error listing projects of organization (%s)", orgId), fmt.Sprintf("%s", err))
+		return
+	}
+	var found *models.ProjectInfo
+	for _, pj := range projects {
+		if pj.Name == name {
+			found = pj
+			break
+		}
+	}
+	if found == nil {
+		resp.Diagnostics.AddError(
+			"Project not found",
+			fmt.Sprintf("Could not find project %s in organization (%s)", name, orgId),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("slug"), found.Slug)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), orgId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("unfollow_on_destroy"), false)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, projectCreatedKey, []byte("true"))...)
 }
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -28,8 +29,58 @@ resource "circleci_project" "p1" {
 					resource.TestCheckResourceAttr("circleci_project.p1", "vcs_url", "https://github.com/kelvintaywl-tf/tf-provider-acceptance-test-dummy"),
 					resource.TestCheckResourceAttr("circleci_project.p1", "vcs_default_branch", "main"),
 					resource.TestCheckResourceAttr("circleci_project.p1", "vcs_provider", "GitHub"),
+					resource.TestCheckResourceAttr("circleci_project.p1", "unfollow_on_destroy", "false"),
 				),
 			},
+			// ImportState testing
+			{
+				ResourceName:      "circleci_project.p1",
+				ImportState:       true,
+				ImportStateId:     projectSlug,
+				ImportStateVerify: true,
+			},
+			// Changing the slug requires a replacement
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_project" "p1" {
+	slug = "%s"
+}
+`, standaloneProjectSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_project.p1", "slug", standaloneProjectSlug),
+					resource.TestCheckResourceAttr("circleci_project.p1", "id", standaloneProjectId),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectResource_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_project" "p2" {
+	organization_id = "%s"
+	name            = "tf-acceptance-test-created"
+}
+`, standaloneOrgId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_project.p2", "name", "tf-acceptance-test-created"),
+					resource.TestCheckResourceAttr("circleci_project.p2", "organization_id", standaloneOrgId),
+					resource.TestCheckResourceAttrSet("circleci_project.p2", "id"),
+					resource.TestMatchResourceAttr("circleci_project.p2", "slug", regexp.MustCompile(`^circleci/`)),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "circleci_project.p2",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s,tf-acceptance-test-created", standaloneOrgId),
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -0,0 +1,286 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ProjectSettingsResource{}
+
+func NewProjectSettingsResource() resource.Resource {
+	return &ProjectSettingsResource{}
+}
+
+type ProjectSettingsResource struct {
+	client *CircleciAPIClient
+}
+
+type ProjectSettingsResourceModel struct {
+	Id                        types.String `tfsdk:"id"`
+	ProjectSlug               types.String `tfsdk:"project_slug"`
+	BuildForkPRs              types.Bool   `tfsdk:"build_fork_prs"`
+	ForksReceiveSecretEnvVars types.Bool   `tfsdk:"forks_receive_secret_env_vars"`
+	BuildPRsOnly              types.Bool   `tfsdk:"build_prs_only"`
+	AutocancelBuilds          types.Bool   `tfsdk:"autocancel_builds"`
+	SetupWorkflows            types.Bool   `tfsdk:"setup_workflows"`
+	OSS                       types.Bool   `tfsdk:"oss"`
+}
+
+// flags returns the settings of the model, by their feature flag in the v1.1 API.
+func (m *ProjectSettingsResourceModel) flags() map[string]*types.Bool {
+	return map[string]*types.Bool{
+		"build-fork-prs":                &m.BuildForkPRs,
+		"forks-receive-secret-env-vars": &m.ForksReceiveSecretEnvVars,
+		"build-prs-only":                &m.BuildPRsOnly,
+		"autocancel-builds":             &m.AutocancelBuilds,
+		"setup-workflows":               &m.SetupWorkflows,
+		"oss":                           &m.OSS,
+	}
+}
+
+// projectSettings is the payload of the v1.1 project settings endpoint.
+type projectSettings struct {
+	FeatureFlags map[string]bool `json:"feature_flags,omitempty"`
+	SSHKeys      []projectSSHKey `json:"ssh_keys,omitempty"`
+}
+
+// projectSSHKey is an additional SSH key of a project, in the v1.1 API.
+type projectSSHKey struct {
+	Hostname    string `json:"hostname"`
+	PublicKey   string `json:"public_key"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+func (r *ProjectSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_settings"
+}
+
+func projectSettingAttribute(description string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: description + " If not set, the current setting is left as-is.",
+		Optional:            true,
+		Computed:            true,
+		// unchanged unless set
+		PlanModifiers: []planmodifier.Bool{
+			boolplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func (r *ProjectSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the advanced settings of a project",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Read-only unique identifier: project slug",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_slug": schema.StringAttribute{
+				MarkdownDescription: "Project slug in the form `vcs-slug/org-name/repo-name`.",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"build_fork_prs":                projectSettingAttribute("Whether to build pull requests from forks."),
+			"forks_receive_secret_env_vars": projectSettingAttribute("Whether to pass secrets (e.g., environment variables) to builds of pull requests from forks."),
+			"build_prs_only":                projectSettingAttribute("Whether to only build pull requests, and the default branch."),
+			"autocancel_builds":             projectSettingAttribute("Whether to auto-cancel redundant builds on non-default branches."),
+			"setup_workflows":               projectSettingAttribute("Whether to enable setup workflows (i.e., dynamic config)."),
+			"oss":                           projectSettingAttribute("Whether the project is open-source, i.e., builds are visible to everyone."),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *ProjectSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ProjectSettingsResource) settingsURL(projectSlug string) string {
+	return r.client.apiURL(fmt.Sprintf("/api/v1.1/project/%s/settings", projectSlug))
+}
+
+// readSettings sets every setting of the model from the project's current settings.
+func (r *ProjectSettingsResource) readSettings(ctx context.Context, m *ProjectSettingsResourceModel) error {
+	var settings projectSettings
+	if err := r.client.doJSON(ctx, http.MethodGet, r.settingsURL(m.ProjectSlug.ValueString()), nil, &settings); err != nil {
+		return err
+	}
+
+	for flag, v := range m.flags() {
+		*v = types.BoolValue(settings.FeatureFlags[flag])
+	}
+	return nil
+}
+
+// writeSettings updates only the settings which are planned and differ from the prior state, if any.
+func (r *ProjectSettingsResource) writeSettings(ctx context.Context, plan, prior *ProjectSettingsResourceModel) error {
+	priorFlags := map[string]*types.Bool{}
+	if prior != nil {
+		priorFlags = prior.flags()
+	}
+
+	changed := map[string]bool{}
+	for flag, v := range plan.flags() {
+		if v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		if p, ok := priorFlags[flag]; ok && p.Equal(*v) {
+			continue
+		}
+		changed[flag] = v.ValueBool()
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(changed))
+	for flag := range changed {
+		names = append(names, flag)
+	}
+	sort.Strings(names)
+	tflog.Info(ctx, fmt.Sprintf("Updating project settings: %v", names))
+
+	projectSlug := plan.ProjectSlug.ValueString()
+	unlock := r.client.lockProject(projectSlug)
+	defer unlock()
+
+	body := projectSettings{FeatureFlags: changed}
+	return r.client.doJSON(ctx, http.MethodPut, r.settingsURL(projectSlug), body, nil)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ProjectSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state ProjectSettingsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectSlug := state.ProjectSlug.ValueString()
+	if err := r.readSettings(ctx, &state); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error reading Project(%s) settings", projectSlug), fmt.Sprintf("%s", err))
+		return
+	}
+	state.Id = state.ProjectSlug
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ProjectSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan ProjectSettingsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectSlug := plan.ProjectSlug.ValueString()
+	if err := r.writeSettings(ctx, &plan, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating project settings",
+			fmt.Sprintf("Could not update project(%s) settings, unexpected error: %s", projectSlug, err.Error()),
+		)
+		return
+	}
+
+	if err := r.readSettings(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error reading Project(%s) settings", projectSlug), fmt.Sprintf("%s", err))
+		return
+	}
+	plan.Id = plan.ProjectSlug
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ProjectSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ProjectSettingsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectSlug := plan.ProjectSlug.ValueString()
+	if err := r.writeSettings(ctx, &plan, &state); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating project settings",
+			fmt.Sprintf("Could not update project(%s) settings, unexpected error: %s", projectSlug, err.Error()),
+		)
+		return
+	}
+
+	if err := r.readSettings(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error reading Project(%s) settings", projectSlug), fmt.Sprintf("%s", err))
+		return
+	}
+	plan.Id = plan.ProjectSlug
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ProjectSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// not implemented; not possible to delete project settings
+	tflog.Warn(ctx, "Project settings cannot be deleted via this provider; they are left as-is.")
+}
+
+func (r *ProjectSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_slug"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccProjectSettingsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_project_settings" "s1" {
+	project_slug      = "%s"
+	autocancel_builds = true
+}
+`, projectSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_project_settings.s1", "id", projectSlug),
+					resource.TestCheckResourceAttr("circleci_project_settings.s1", "autocancel_builds", "true"),
+					resource.TestCheckResourceAttrSet("circleci_project_settings.s1", "build_fork_prs"),
+					resource.TestCheckResourceAttrSet("circleci_project_settings.s1", "oss"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "circleci_project_settings.s1",
+				ImportState:       true,
+				ImportStateId:     projectSlug,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_project_settings" "s1" {
+	project_slug      = "%s"
+	autocancel_builds = false
+}
+`, projectSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_project_settings.s1", "autocancel_builds", "false"),
+				),
+			},
+		},
+	})
+}
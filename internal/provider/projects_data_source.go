@@ -0,0 +1,322 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kelvintaywl/circleci-go-sdk/client/project"
+	"github.com/kelvintaywl/circleci-go-sdk/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ProjectsDataSource{}
+
+func NewProjectsDataSource() datasource.DataSource {
+	return &ProjectsDataSource{}
+}
+
+type ProjectsDataSource struct {
+	client *CircleciAPIClient
+}
+
+// ProjectsDataSourceModel describes the data source data model.
+type ProjectsDataSourceModel struct {
+	Id               types.String             `tfsdk:"id"`
+	OrganizationSlug types.String             `tfsdk:"organization_slug"`
+	NameRegex        types.String             `tfsdk:"name_regex"`
+	VcsProvider      types.String             `tfsdk:"vcs_provider"`
+	Followed         types.Bool               `tfsdk:"followed"`
+	Projects         []ProjectDataSourceModel `tfsdk:"projects"`
+}
+
+// shortVcsSlug returns the short form of a VCS slug (e.g., gh for github).
+func shortVcsSlug(vcs string) string {
+	switch vcs {
+	case "github":
+		return "gh"
+	case "bitbucket":
+		return "bb"
+	}
+	return vcs
+}
+
+// isStandaloneOrg returns true if the organization slug (e.g., circleci/<org-id>) is of a standalone organization,
+// i.e., a GitLab or GitHub App organization.
+func isStandaloneOrg(organizationSlug string) bool {
+	vcs, _, _ := strings.Cut(organizationSlug, "/")
+	return vcs == "circleci"
+}
+
+// listOrgProjects lists all the projects of an organization, by slug (e.g., gh/org-name or circleci/<org-id>) or ID, via the v2 API.
+func listOrgProjects(ctx context.Context, client *CircleciAPIClient, organizationSlug string) ([]*models.ProjectInfo, error) {
+	if vcs, org, ok := strings.Cut(organizationSlug, "/"); ok {
+		organizationSlug = fmt.Sprintf("%s/%s", shortVcsSlug(vcs), org)
+	}
+
+	var projects []*models.ProjectInfo
+	nextToken := ""
+	for {
+		query := url.Values{}
+		if nextToken != "" {
+			query.Set("page-token", nextToken)
+		}
+		var page struct {
+			Items         []*models.ProjectInfo `json:"items"`
+			NextPageToken string                `json:"next_page_token"`
+		}
+		u := client.apiURL(fmt.Sprintf("/api/v2/organization/%s/project?%s", organizationSlug, query.Encode()))
+		if err := client.doJSON(ctx, http.MethodGet, u, nil, &page); err != nil {
+			return nil, err
+		}
+		projects = append(projects, page.Items...)
+
+		nextToken = page.NextPageToken
+		if nextToken == "" {
+			return projects, nil
+		}
+	}
+}
+
+// followedProjectSlugs returns the slugs of the projects of an organization (e.g., gh/org-name)
+// followed by the user of the API token, via the v1.1 API.
+func followedProjectSlugs(ctx context.Context, client *CircleciAPIClient, organizationSlug string) (map[string]bool, error) {
+	repos, err := listV1Repos(ctx, client, organizationSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	followed := map[string]bool{}
+	for _, repo := range matchRepos(repos, organizationSlug, []*regexp.Regexp{regexp.MustCompile(`.*`)}, nil) {
+		if repo.Following {
+			followed[repo.slug()] = true
+		}
+	}
+	return followed, nil
+}
+
+// filterProjects returns the projects matching the filters, if any, sorted by slug.
+// The VCS provider (e.g., GitHub) is matched case-insensitively, and followed is nil unless filtering on followed projects.
+func filterProjects(projects []*models.ProjectInfo, nameRegex *regexp.Regexp, vcsProvider string, followed *bool, followedSlugs map[string]bool) []*models.ProjectInfo {
+	matches := []*models.ProjectInfo{}
+	for _, pj := range projects {
+		if nameRegex != nil && !nameRegex.MatchString(pj.Name) {
+			continue
+		}
+		if vcsProvider != "" && (pj.VcsInfo == nil || !strings.EqualFold(pj.VcsInfo.Provider, vcsProvider)) {
+			continue
+		}
+		vcs, rest, _ := strings.Cut(pj.Slug, "/")
+		if followed != nil && followedSlugs[fmt.Sprintf("%s/%s", shortVcsSlug(vcs), rest)] != *followed {
+			continue
+		}
+		matches = append(matches, pj)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Slug < matches[j].Slug })
+	return matches
+}
+
+func (d *ProjectsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_projects"
+}
+
+func (d *ProjectsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Fetches the list of projects in an organization.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier of this data source: organization slug.",
+				Computed:            true,
+			},
+			"organization_slug": schema.StringAttribute{
+				MarkdownDescription: "Organization slug in the form `vcs-slug/org-name` (e.g., `gh/acme` or `github/acme`), or `circleci/<org-id>` for standalone (GitLab or GitHub App) organizations, as in their project slugs.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[^/]+/[^/]+$`),
+						"must be in the form vcs-slug/org-name",
+					),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only list projects whose name matches this regular expression.",
+				Optional:            true,
+			},
+			"vcs_provider": schema.StringAttribute{
+				MarkdownDescription: "Only list projects of this VCS provider (either GitHub, Bitbucket or CircleCI).",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("GitHub", "Bitbucket", "CircleCI"),
+				},
+			},
+			"followed": schema.BoolAttribute{
+				MarkdownDescription: "Only list projects followed (or not followed, if false) by the user of the API token. Not supported for standalone organizations, whose projects are not followed.",
+				Optional:            true,
+			},
+			"projects": schema.ListNestedAttribute{
+				MarkdownDescription: "List of projects",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Unique identifier of this project",
+							Computed:            true,
+						},
+						"slug": schema.StringAttribute{
+							MarkdownDescription: "Project slug in the form `vcs-slug/org-name/repo-name`.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the project",
+							Computed:            true,
+						},
+						"organization_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the organization the project belongs to",
+							Computed:            true,
+						},
+						"organization_slug": schema.StringAttribute{
+							MarkdownDescription: "The slug of the organization the project belongs to",
+							Computed:            true,
+						},
+						"organization_id": schema.StringAttribute{
+							MarkdownDescription: "The id of the organization the project belongs to",
+							Computed:            true,
+						},
+						"vcs_url": schema.StringAttribute{
+							MarkdownDescription: "URL to the repository hosting the project's code",
+							Computed:            true,
+						},
+						"vcs_provider": schema.StringAttribute{
+							MarkdownDescription: "VCS provider (either GitHub, Bitbucket or CircleCI)",
+							Computed:            true,
+						},
+						"vcs_default_branch": schema.StringAttribute{
+							MarkdownDescription: "Default branch of this project",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ProjectsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ProjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ProjectsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		re, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid name_regex", fmt.Sprintf("%s", err))
+			return
+		}
+		nameRegex = re
+	}
+
+	organizationSlug := data.OrganizationSlug.ValueString()
+	var followed *bool
+	var followedSlugs map[string]bool
+	if !data.Followed.IsNull() {
+		if isStandaloneOrg(organizationSlug) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("followed"),
+				"Invalid followed filter",
+				"Projects of standalone organizations are not followed, so they cannot be filtered on followed.",
+			)
+			return
+		}
+		v := data.Followed.ValueBool()
+		followed = &v
+
+		slugs, err := followedProjectSlugs(ctx, d.client, organizationSlug)
+		if err != nil {
+			resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
+			return
+		}
+		followedSlugs = slugs
+	}
+
+	projects, err := listOrgProjects(ctx, d.client, organizationSlug)
+	if err != nil {
+		resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
+		return
+	}
+
+	data.Projects = []ProjectDataSourceModel{}
+	for _, pj := range filterProjects(projects, nameRegex, data.VcsProvider.ValueString(), followed, followedSlugs) {
+		// projects are only fetched in turn if listed without their VCS information
+		if pj.VcsInfo == nil {
+			param := project.NewGetProjectParamsWithContext(ctx).WithDefaults()
+			param = param.WithProjectSlug(pj.Slug)
+
+			res, err := d.client.Client.Project.GetProject(param, d.client.Auth)
+			if err != nil {
+				// e.g., renamed or deleted since listed
+				var notFound *project.GetProjectNotFound
+				if errors.As(err, &notFound) {
+					tflog.Warn(ctx, fmt.Sprintf("Project no longer found: %s", pj.Slug))
+					continue
+				}
+				resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
+				return
+			}
+			pj = res.GetPayload()
+		}
+
+		m := ProjectDataSourceModel{Slug: types.StringValue(pj.Slug)}
+		m.fromAPI(pj)
+		data.Projects = append(data.Projects, m)
+	}
+	data.Id = data.OrganizationSlug
+
+	// Save data into Terraform state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/kelvintaywl/circleci-go-sdk/models"
+)
+
+func TestFilterProjects(t *testing.T) {
+	projects := []*models.ProjectInfo{
+		{Slug: "gh/acme/web", Name: "web", VcsInfo: &models.ProjectInfoVcsInfo{Provider: "GitHub"}},
+		{Slug: "gh/acme/api", Name: "api", VcsInfo: &models.ProjectInfoVcsInfo{Provider: "GitHub"}},
+		{Slug: "circleci/org/api-docs", Name: "api-docs", VcsInfo: &models.ProjectInfoVcsInfo{Provider: "CircleCI"}},
+		{Slug: "gh/acme/cli", Name: "cli"},
+	}
+	followedSlugs := map[string]bool{"gh/acme/web": true}
+	yes, no := true, false
+
+	for _, tc := range []struct {
+		name        string
+		nameRegex   *regexp.Regexp
+		vcsProvider string
+		followed    *bool
+		expected    []string
+	}{
+		{"all", nil, "", nil, []string{"circleci/org/api-docs", "gh/acme/api", "gh/acme/cli", "gh/acme/web"}},
+		{"name regex", regexp.MustCompile(`^api`), "", nil, []string{"circleci/org/api-docs", "gh/acme/api"}},
+		{"vcs provider", nil, "github", nil, []string{"gh/acme/api", "gh/acme/web"}},
+		{"followed", nil, "", &yes, []string{"gh/acme/web"}},
+		{"not followed", regexp.MustCompile(`^[a-z]+$`), "", &no, []string{"gh/acme/api", "gh/acme/cli"}},
+		{"no match", regexp.MustCompile(`^mobile$`), "", nil, []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []string{}
+			for _, pj := range filterProjects(projects, tc.nameRegex, tc.vcsProvider, tc.followed, followedSlugs) {
+				got = append(got, pj.Slug)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsStandaloneOrg(t *testing.T) {
+	for slug, expected := range map[string]bool{
+		"circleci/c124cca6-d03e-4733-b84d-32b02347b78c": true,
+		"gh/acme":     false,
+		"github/acme": false,
+		"bb/acme":     false,
+	} {
+		if got := isStandaloneOrg(slug); got != expected {
+			t.Errorf("%s: expected %v, got %v", slug, expected, got)
+		}
+	}
+}
+
+func TestAccProjectsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "circleci_projects" "all" {
+  organization_slug = "gh/kelvintaywl-tf"
+  name_regex        = "^tf-provider-acceptance-test-dummy$"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_projects.all", "id", "gh/kelvintaywl-tf"),
+					resource.TestCheckResourceAttr("data.circleci_projects.all", "projects.#", "1"),
+					resource.TestCheckResourceAttr("data.circleci_projects.all", "projects.0.id", projectId),
+					resource.TestCheckResourceAttr("data.circleci_projects.all", "projects.0.slug", "gh/kelvintaywl-tf/tf-provider-acceptance-test-dummy"),
+					resource.TestCheckResourceAttr("data.circleci_projects.all", "projects.0.vcs_default_branch", "main"),
+				),
+			},
+			// standalone organization
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "circleci_projects" "standalone" {
+  organization_slug = "%s"
+}`, standaloneOrgSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_projects.standalone", "id", standaloneOrgSlug),
+					resource.TestCheckTypeSetElemNestedAttrs("data.circleci_projects.standalone", "projects.*", map[string]string{
+						"id":   standaloneProjectId,
+						"slug": standaloneProjectSlug,
+					}),
+				),
+			},
+		},
+	})
+}
@@ -17,8 +17,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	api "github.com/kelvintaywl/circleci-go-sdk/client"
@@ -46,11 +49,29 @@ type CircleciProvider struct {
 }
 
 type CircleciAPIClient struct {
-	Client       *api.Circleci
-	RunnerClient *rapi.Circleci
-	V1Client     *http.Client
-	Hostname     string
-	Auth         runtime.ClientAuthInfoWriter
+	Client         *api.Circleci
+	RunnerClient   *rapi.Circleci
+	V1Client       *http.Client
+	Hostname       string
+	RunnerHostname string
+	Auth           runtime.ClientAuthInfoWriter
+
+	// shared listings of context env vars, for this Terraform operation only
+	contextEnvVars *contextEnvVarCache
+	// serializes writes per context ID and per project slug
+	writeLocks *keyedMutex
+	// only allow write-only secrets, of which salted hashes are kept in state
+	hashSecrets bool
+	// local PGP keyring file, to resolve keybase:<username> PGP keys against
+	pgpKeyring string
+}
+
+// pgpKeyringFile returns the local PGP keyring file, if any, even before the provider is configured.
+func (c *CircleciAPIClient) pgpKeyringFile() string {
+	if c == nil {
+		return ""
+	}
+	return c.pgpKeyring
 }
 
 type httpClientTransport struct {
@@ -80,6 +101,14 @@ func (t *retryOn429Transport) RoundTrip(req *http.Request) (*http.Response, erro
 			return resp, err
 		}
 		resp.Body.Close()
+		// the body was consumed by the previous attempt
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
 		backoffMs := t.Backoff(attempts)
 		tflog.Debug(req.Context(), fmt.Sprintf("Received HTTP 429, retrying in %d ms (attempt %d/%d)", backoffMs, attempts+1, t.MaxRetries))
 		time.Sleep(time.Duration(backoffMs) * time.Millisecond)
@@ -93,6 +122,10 @@ type CircleciProviderModel struct {
 	Hostname   types.String `tfsdk:"hostname"`
 	Retry      types.Bool   `tfsdk:"retry"`
 	MaxRetries types.Int64  `tfsdk:"max_retries"`
+
+	WriteLockGranularity types.String `tfsdk:"write_lock_granularity"`
+	StoreSecretsInState  types.Bool   `tfsdk:"store_secrets_in_state"`
+	PGPKeyring           types.String `tfsdk:"pgp_keyring"`
 }
 
 func (p *CircleciProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -119,6 +152,21 @@ func (p *CircleciProvider) Schema(ctx context.Context, req provider.SchemaReques
 				MarkdownDescription: "Maximum number of retries for API calls when retry is enabled (default: 3).",
 				Optional:            true,
 			},
+			"write_lock_granularity": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How to serialize writes of env vars and checkout keys, to avoid conflicts and rate-limits. Accepts `none`, `keyed` (per context ID or project slug) or `global` (default: %s).", writeLockKeyed),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(vWriteLockGranularities...),
+				},
+			},
+			"store_secrets_in_state": schema.BoolAttribute{
+				MarkdownDescription: "Whether configured secrets (env var values, webhook signing secrets and additional SSH keys) may be kept in the Terraform state as-is (default: true). When false, they must be set via their write-only attributes instead (e.g., `value_wo`), of which only a salted hash is kept in state, and generated tokens require `pgp_key`, to be kept encrypted in state.",
+				Optional:            true,
+			},
+			"pgp_keyring": schema.StringAttribute{
+				MarkdownDescription: "Path to a local PGP keyring file (armored or binary), to resolve `keybase:<username>` values of `pgp_key` against. Keys are not fetched from Keybase, so export them first (e.g., via `keybase pgp export -q <key-id>`). This can also be set via the `CIRCLE_PGP_KEYRING` environment variable.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -129,11 +177,15 @@ func (p *CircleciProvider) Configure(ctx context.Context, req provider.Configure
 	// Check environment variables
 	apiToken := os.Getenv("CIRCLE_TOKEN")
 	hostname := os.Getenv("CIRCLE_HOSTNAME")
+	pgpKeyring := os.Getenv("CIRCLE_PGP_KEYRING")
 
 	// Retry settings
 	retry := false
 	maxRetries := int64(3)
 
+	writeLockGranularity := writeLockKeyed
+	storeSecretsInState := true
+
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -157,6 +209,17 @@ func (p *CircleciProvider) Configure(ctx context.Context, req provider.Configure
 		maxRetries = data.MaxRetries.ValueInt64()
 	}
 
+	if data.WriteLockGranularity.ValueString() != "" {
+		writeLockGranularity = data.WriteLockGranularity.ValueString()
+	}
+
+	if !data.StoreSecretsInState.IsNull() {
+		storeSecretsInState = data.StoreSecretsInState.ValueBool()
+	}
+
+	if data.PGPKeyring.ValueString() != "" {
+		pgpKeyring = data.PGPKeyring.ValueString()
+	}
 	if apiToken == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_token"),
@@ -190,6 +253,8 @@ func (p *CircleciProvider) Configure(ctx context.Context, req provider.Configure
 	}
 
 	var rclient *rapi.Circleci
+	// the V1 client also calls endpoints not covered by the SDKs, which are retried likewise
+	httpClient := &http.Client{Transport: defaultTransport}
 	if retry {
 		// Add retry transport for 429s
 		retryTransport := &retryOn429Transport{
@@ -203,18 +268,23 @@ func (p *CircleciProvider) Configure(ctx context.Context, req provider.Configure
 		transport := rtc.New(rcfg.Host, rcfg.BasePath, rcfg.Schemes)
 		transport.Transport = retryTransport
 		rclient = rapi.New(transport, strfmt.Default)
+		httpClient.Transport = retryTransport
 	} else {
 		rclient = rapi.NewHTTPClientWithConfig(strfmt.Default, rcfg)
 	}
 
-	httpClient := &http.Client{Transport: defaultTransport}
-
 	apiClient := &CircleciAPIClient{
-		Client:       client,
-		RunnerClient: rclient,
-		V1Client:     httpClient,
-		Hostname:     hostname,
-		Auth:         auth,
+		Client:         client,
+		RunnerClient:   rclient,
+		V1Client:       httpClient,
+		Hostname:       hostname,
+		RunnerHostname: rhostname,
+		Auth:           auth,
+
+		contextEnvVars: newContextEnvVarCache(),
+		writeLocks:     newKeyedMutex(writeLockGranularity),
+		hashSecrets:    !storeSecretsInState,
+		pgpKeyring:     pgpKeyring,
 	}
 
 	resp.DataSourceData = apiClient
@@ -226,22 +296,38 @@ func (p *CircleciProvider) Resources(ctx context.Context) []func() resource.Reso
 		NewWebhookResource,
 		NewScheduleResource,
 		NewEnvVarResource,
+		NewProjectEnvVarsResource,
 		NewCheckoutKeyResource,
+		NewAdditionalSSHKeyResource,
+		NewProjectAPITokenResource,
 		NewContextResource,
 		NewContextEnvVarResource,
+		NewContextEnvVarsResource,
+		NewContextRestrictionResource,
+		NewOrgOIDCClaimsResource,
+		NewProjectOIDCClaimsResource,
 		NewRunnerResourceClassResource,
 		NewRunnerTokenResource,
 		NewProjectResource,
+		NewProjectSettingsResource,
+		NewFollowedProjectsResource,
+		NewPipelineDefinitionResource,
+		NewTriggerResource,
 	}
 }
 
 func (p *CircleciProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewProjectDataSource,
+		NewProjectsDataSource,
 		NewWebhooksDataSource,
 		NewCheckoutKeysDataSource,
 		NewContextDataSource,
+		NewContextsDataSource,
 		NewRunnerResourceClassesDataSource,
 		NewRunnerTokensDataSource,
+		NewRunnerInstancesDataSource,
+		NewRunnerUnclaimedTasksDataSource,
+		NewRunnerAgentConfigDataSource,
 	}
 }
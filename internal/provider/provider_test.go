@@ -39,7 +39,8 @@ provider "circleci" {
 	standaloneProjectSlug string = "circleci/7UQdtYSr1caLbAR2cHJdU7/2DACeEvUr7MosidActmnUs"
 
 	// org circleci/7UQdtYSr1caLbAR2cHJdU7
-	standaloneOrgId string = "346a7ade-9fae-47ec-b729-da3d5afbe4fc"
+	standaloneOrgId   string = "346a7ade-9fae-47ec-b729-da3d5afbe4fc"
+	standaloneOrgSlug string = "circleci/7UQdtYSr1caLbAR2cHJdU7"
 
 	// context "data-source-for-tf" under circleci/7UQdtYSr1caLbAR2cHJdU7
 	standaloneContextId   string = "bb83cbf7-d20d-4224-9f70-3516111120a7"
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+// apiError is an unexpected response from a CircleCI API called without an SDK.
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Message)
+}
+
+// isNotFound returns true if the error is an HTTP 404 response.
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// apiURL returns the URL of a CircleCI API path (e.g., /api/v2/...).
+func (c *CircleciAPIClient) apiURL(path string) string {
+	return fmt.Sprintf("https://%s%s", c.Hostname, path)
+}
+
+// runnerAPIURL returns the URL of a CircleCI runner API path, e.g., for the API URL of Runner agent configurations.
+// Runner API endpoints not covered by the runner SDK are called via doRunnerJSON instead.
+func (c *CircleciAPIClient) runnerAPIURL(path string) string {
+	return fmt.Sprintf("https://%s%s", c.RunnerHostname, path)
+}
+
+// doJSON calls a CircleCI API endpoint not covered by the SDKs.
+// The body, if any, is sent as JSON, and the JSON response is decoded into out, if any.
+func (c *CircleciAPIClient) doJSON(ctx context.Context, method, url string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.V1Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	return decodeJSONResponse(res.StatusCode, b, out)
+}
+
+// decodeJSONResponse decodes the JSON response into out, if any, or returns an apiError for unexpected status codes.
+func decodeJSONResponse(statusCode int, b []byte, out interface{}) error {
+	if statusCode < 200 || statusCode >= 300 {
+		var msg struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(b, &msg) != nil || msg.Message == "" {
+			msg.Message = strings.TrimSpace(string(b))
+		}
+		return &apiError{StatusCode: statusCode, Message: msg.Message}
+	}
+
+	if out == nil || len(b) == 0 {
+		return nil
+	}
+	return json.Unmarshal(b, out)
+}
+
+// doRunnerJSON calls a CircleCI runner API endpoint not covered by the runner SDK, through the transport of the runner client.
+// The path is relative to the base path of the runner API (e.g., /v3/runner/tasks), and the JSON response is decoded into out, if any.
+func (c *CircleciAPIClient) doRunnerJSON(ctx context.Context, method, path string, query url.Values, out interface{}) error {
+	_, err := c.RunnerClient.Transport.Submit(&runtime.ClientOperation{
+		ID:                 fmt.Sprintf("%s %s", method, path),
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		AuthInfo:           c.Auth,
+		Params: runtime.ClientRequestWriterFunc(func(req runtime.ClientRequest, _ strfmt.Registry) error {
+			for key, values := range query {
+				if err := req.SetQueryParam(key, values...); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(res runtime.ClientResponse, _ runtime.Consumer) (interface{}, error) {
+			b, err := io.ReadAll(res.Body())
+			if err != nil {
+				return nil, err
+			}
+			return nil, decodeJSONResponse(res.Code(), b, out)
+		}),
+		Context: ctx,
+	})
+	return err
+}
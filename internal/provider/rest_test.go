@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	rtc "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	rapi "github.com/kelvintaywl/circleci-runner-go-sdk/client"
+)
+
+func TestDoJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			if r.Header.Get("Circle-Token") != "token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"name": "FOOBAR"}`))
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not found."}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`oops`))
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &CircleciAPIClient{
+		V1Client: &http.Client{Transport: &httpClientTransport{APIToken: "token"}},
+	}
+
+	var out struct {
+		Name string `json:"name"`
+	}
+	if err := client.doJSON(ctx, http.MethodGet, server.URL+"/ok", nil, &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if out.Name != "FOOBAR" {
+		t.Errorf("expected decoded response, got %q", out.Name)
+	}
+
+	err := client.doJSON(ctx, http.MethodGet, server.URL+"/missing", nil, &out)
+	if !isNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if err != nil && err.Error() != "unexpected status code 404: Not found." {
+		t.Errorf("unexpected error message: %s", err)
+	}
+
+	err = client.doJSON(ctx, http.MethodGet, server.URL+"/error", nil, &out)
+	if err == nil || isNotFound(err) || err.Error() != "unexpected status code 500: oops" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDoJSONRetry(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(b)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := &CircleciAPIClient{
+		V1Client: &http.Client{Transport: &retryOn429Transport{
+			Base:       &httpClientTransport{APIToken: "token"},
+			MaxRetries: 1,
+			Backoff:    func(attempt int) int { return 0 },
+		}},
+	}
+
+	var out struct {
+		Name string `json:"name"`
+	}
+	if err := client.doJSON(ctx, http.MethodPost, server.URL, map[string]string{"name": "FOOBAR"}, &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+	if out.Name != "FOOBAR" {
+		t.Errorf("expected the body to be sent again, got %q", out.Name)
+	}
+}
+
+func TestDoRunnerJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Circle-Token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v3/runner/tasks":
+			_, _ = w.Write([]byte(`{"unclaimed_task_count": ` + r.URL.Query().Get("count") + `}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not found."}`))
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	transport := rtc.New(strings.TrimPrefix(server.URL, "http://"), rapi.DefaultBasePath, []string{"http"})
+	client := &CircleciAPIClient{
+		RunnerClient: rapi.New(transport, strfmt.Default),
+		Auth:         rtc.APIKeyAuth("Circle-Token", "header", "token"),
+	}
+
+	var out struct {
+		UnclaimedTaskCount int64 `json:"unclaimed_task_count"`
+	}
+	if err := client.doRunnerJSON(ctx, http.MethodGet, "/v3/runner/tasks", url.Values{"count": {"2"}}, &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if out.UnclaimedTaskCount != 2 {
+		t.Errorf("expected decoded response, got %d", out.UnclaimedTaskCount)
+	}
+
+	err := client.doRunnerJSON(ctx, http.MethodGet, "/v3/runner/missing", nil, &out)
+	if !isNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,321 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	runnerTypeMachine   string = "machine"
+	runnerTypeContainer string = "container"
+
+	defaultRunnerWorkingDirectory string = "/var/opt/circleci/workdir"
+)
+
+var vRunnerTypes = []string{
+	runnerTypeMachine,
+	runnerTypeContainer,
+}
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &RunnerAgentConfigDataSource{}
+
+func NewRunnerAgentConfigDataSource() datasource.DataSource {
+	return &RunnerAgentConfigDataSource{}
+}
+
+type RunnerAgentConfigDataSource struct {
+	client *CircleciAPIClient
+}
+
+type RunnerAgentConfigDataSourceModel struct {
+	Type                      types.String `tfsdk:"type"`
+	ResourceClass             types.String `tfsdk:"resource_class"`
+	Token                     types.String `tfsdk:"token"`
+	Name                      types.String `tfsdk:"name"`
+	WorkingDirectory          types.String `tfsdk:"working_directory"`
+	CleanupWorkingDirectory   types.Bool   `tfsdk:"cleanup_working_directory"`
+	MaxRunTime                types.String `tfsdk:"max_run_time"`
+	ContainerResourceLimits   types.Map    `tfsdk:"container_resource_limits"`
+	ContainerResourceRequests types.Map    `tfsdk:"container_resource_requests"`
+	APIURL                    types.String `tfsdk:"api_url"`
+	Rendered                  types.String `tfsdk:"rendered"`
+	Id                        types.String `tfsdk:"id"`
+}
+
+// runnerAgentConfig holds the options to render a Runner agent configuration.
+type runnerAgentConfig struct {
+	Type                      string
+	ResourceClass             string
+	Token                     string
+	APIURL                    string
+	Name                      string
+	WorkingDirectory          string
+	CleanupWorkingDirectory   *bool
+	MaxRunTime                string
+	ContainerResourceLimits   map[string]string
+	ContainerResourceRequests map[string]string
+}
+
+// launch-agent-config.yaml of machine runners
+type machineRunnerConfig struct {
+	API struct {
+		AuthToken string `yaml:"auth_token"`
+		URL       string `yaml:"url"`
+	} `yaml:"api"`
+	Runner struct {
+		Name                    string `yaml:"name"`
+		WorkingDirectory        string `yaml:"working_directory"`
+		CleanupWorkingDirectory *bool  `yaml:"cleanup_working_directory,omitempty"`
+		MaxRunTime              string `yaml:"max_run_time,omitempty"`
+	} `yaml:"runner"`
+}
+
+// values.yaml of the container agent Helm chart
+type containerAgentValues struct {
+	Agent struct {
+		RunnerAPI       string                                 `yaml:"runnerAPI"`
+		MaxRunTime      string                                 `yaml:"maxRunTime,omitempty"`
+		ResourceClasses map[string]containerAgentResourceClass `yaml:"resourceClasses"`
+	} `yaml:"agent"`
+}
+
+type containerAgentResourceClass struct {
+	Token string                  `yaml:"token"`
+	Spec  *containerAgentTaskSpec `yaml:"spec,omitempty"`
+}
+
+type containerAgentTaskSpec struct {
+	Containers []containerAgentContainer `yaml:"containers"`
+}
+
+type containerAgentContainer struct {
+	Resources struct {
+		Limits   map[string]string `yaml:"limits,omitempty"`
+		Requests map[string]string `yaml:"requests,omitempty"`
+	} `yaml:"resources"`
+}
+
+// renderRunnerAgentConfig renders the machine runner or container agent configuration as YAML.
+func renderRunnerAgentConfig(c runnerAgentConfig) (string, error) {
+	var config interface{}
+
+	switch c.Type {
+	case runnerTypeMachine:
+		mc := machineRunnerConfig{}
+		mc.API.AuthToken = c.Token
+		mc.API.URL = c.APIURL
+		mc.Runner.Name = c.Name
+		if mc.Runner.Name == "" {
+			// <namespace>/<name>
+			mc.Runner.Name = c.ResourceClass[strings.Index(c.ResourceClass, "/")+1:]
+		}
+		mc.Runner.WorkingDirectory = c.WorkingDirectory
+		if mc.Runner.WorkingDirectory == "" {
+			mc.Runner.WorkingDirectory = defaultRunnerWorkingDirectory
+		}
+		mc.Runner.CleanupWorkingDirectory = c.CleanupWorkingDirectory
+		mc.Runner.MaxRunTime = c.MaxRunTime
+		config = mc
+	case runnerTypeContainer:
+		rc := containerAgentResourceClass{Token: c.Token}
+		if len(c.ContainerResourceLimits) > 0 || len(c.ContainerResourceRequests) > 0 {
+			container := containerAgentContainer{}
+			container.Resources.Limits = c.ContainerResourceLimits
+			container.Resources.Requests = c.ContainerResourceRequests
+			rc.Spec = &containerAgentTaskSpec{Containers: []containerAgentContainer{container}}
+		}
+		values := containerAgentValues{}
+		values.Agent.RunnerAPI = c.APIURL
+		values.Agent.MaxRunTime = c.MaxRunTime
+		values.Agent.ResourceClasses = map[string]containerAgentResourceClass{c.ResourceClass: rc}
+		config = values
+	default:
+		return "", fmt.Errorf("unsupported runner type: %s", c.Type)
+	}
+
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(config); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (d *RunnerAgentConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_runner_agent_config"
+}
+
+func (d *RunnerAgentConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Renders the configuration of a machine runner (`launch-agent-config.yaml`) or of the container agent (Helm `values.yaml`) for a Runner resource-class and token.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The type of Runner agent: %s.", strings.Join(vRunnerTypes, ", ")),
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(vRunnerTypes...),
+				},
+			},
+			"resource_class": schema.StringAttribute{
+				MarkdownDescription: "The name of the Runner resource-class (should include namespace)",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^.+\/.+`),
+						"must follow <namespace>/<name>"),
+				},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The Runner token value.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the machine runner (default: the name of the Runner resource-class, without its namespace). Only for `machine` type.",
+				Optional:            true,
+			},
+			"working_directory": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The working directory of the machine runner (default: %s). Only for `machine` type.", defaultRunnerWorkingDirectory),
+				Optional:            true,
+			},
+			"cleanup_working_directory": schema.BoolAttribute{
+				MarkdownDescription: "Whether the machine runner removes the working directory after each job. Only for `machine` type.",
+				Optional:            true,
+			},
+			"max_run_time": schema.StringAttribute{
+				MarkdownDescription: "The maximum time a job can run for (e.g., `5h`).",
+				Optional:            true,
+			},
+			"container_resource_limits": schema.MapAttribute{
+				MarkdownDescription: "Resource limits of the job containers (e.g., `cpu` and `memory`). Only for `container` type.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"container_resource_requests": schema.MapAttribute{
+				MarkdownDescription: "Resource requests of the job containers (e.g., `cpu` and `memory`). Only for `container` type.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"api_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the runner API, based on the provider's hostname.",
+				Computed:            true,
+			},
+			"rendered": schema.StringAttribute{
+				MarkdownDescription: "The rendered configuration, as YAML.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier of this data source: resource-class.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RunnerAgentConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RunnerAgentConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RunnerAgentConfigDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runnerType := data.Type.ValueString()
+	onlyFor := map[string]bool{
+		"name":                        !data.Name.IsNull() && runnerType != runnerTypeMachine,
+		"working_directory":           !data.WorkingDirectory.IsNull() && runnerType != runnerTypeMachine,
+		"cleanup_working_directory":   !data.CleanupWorkingDirectory.IsNull() && runnerType != runnerTypeMachine,
+		"container_resource_limits":   !data.ContainerResourceLimits.IsNull() && runnerType != runnerTypeContainer,
+		"container_resource_requests": !data.ContainerResourceRequests.IsNull() && runnerType != runnerTypeContainer,
+	}
+	for attr, invalid := range onlyFor {
+		if invalid {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attr),
+				"Unsupported attribute for Runner type",
+				fmt.Sprintf("%s is not supported for %s Runner agents", attr, runnerType),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := runnerAgentConfig{
+		Type:             runnerType,
+		ResourceClass:    data.ResourceClass.ValueString(),
+		Token:            data.Token.ValueString(),
+		APIURL:           d.client.runnerAPIURL(""),
+		Name:             data.Name.ValueString(),
+		WorkingDirectory: data.WorkingDirectory.ValueString(),
+		MaxRunTime:       data.MaxRunTime.ValueString(),
+	}
+	if !data.CleanupWorkingDirectory.IsNull() {
+		cleanup := data.CleanupWorkingDirectory.ValueBool()
+		config.CleanupWorkingDirectory = &cleanup
+	}
+	resp.Diagnostics.Append(data.ContainerResourceLimits.ElementsAs(ctx, &config.ContainerResourceLimits, false)...)
+	resp.Diagnostics.Append(data.ContainerResourceRequests.ElementsAs(ctx, &config.ContainerResourceRequests, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rendered, err := renderRunnerAgentConfig(config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error rendering Runner agent configuration", fmt.Sprintf("%s", err))
+		return
+	}
+
+	data.APIURL = types.StringValue(config.APIURL)
+	data.Rendered = types.StringValue(rendered)
+	data.Id = data.ResourceClass
+
+	// Save data into Terraform state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
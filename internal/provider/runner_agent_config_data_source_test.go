@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRunnerAgentConfigDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "circleci_runner_agent_config" "machine" {
+  type           = "machine"
+  resource_class = "%s/%s"
+  token          = "not-a-token"
+  max_run_time   = "5h"
+}`, namespace, resourceClass),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_runner_agent_config.machine", "id", fmt.Sprintf("%s/%s", namespace, resourceClass)),
+					resource.TestCheckResourceAttr("data.circleci_runner_agent_config.machine", "api_url", "https://runner.circleci.com"),
+					resource.TestCheckResourceAttr("data.circleci_runner_agent_config.machine", "rendered", `api:
+  auth_token: not-a-token
+  url: https://runner.circleci.com
+runner:
+  name: test
+  working_directory: /var/opt/circleci/workdir
+  max_run_time: 5h
+`),
+				),
+			},
+		},
+	})
+}
+
+func TestRenderRunnerAgentConfig(t *testing.T) {
+	cleanup := true
+	type testcase struct {
+		config   runnerAgentConfig
+		expected string
+	}
+
+	testcases := []testcase{
+		{
+			runnerAgentConfig{
+				Type:                    runnerTypeMachine,
+				ResourceClass:           "acme/linux",
+				Token:                   "t0k3n",
+				APIURL:                  "https://runner.circleci.com",
+				WorkingDirectory:        "/tmp/workdir",
+				CleanupWorkingDirectory: &cleanup,
+			},
+			`api:
+  auth_token: t0k3n
+  url: https://runner.circleci.com
+runner:
+  name: linux
+  working_directory: /tmp/workdir
+  cleanup_working_directory: true
+`,
+		},
+		{
+			runnerAgentConfig{
+				Type:                    runnerTypeContainer,
+				ResourceClass:           "acme/k8s",
+				Token:                   "t0k3n",
+				APIURL:                  "https://circleci.example.com",
+				MaxRunTime:              "2h",
+				ContainerResourceLimits: map[string]string{"cpu": "1", "memory": "2Gi"},
+			},
+			`agent:
+  runnerAPI: https://circleci.example.com
+  maxRunTime: 2h
+  resourceClasses:
+    acme/k8s:
+      token: t0k3n
+      spec:
+        containers:
+          - resources:
+              limits:
+                cpu: "1"
+                memory: 2Gi
+`,
+		},
+		{
+			runnerAgentConfig{
+				Type:          runnerTypeContainer,
+				ResourceClass: "acme/k8s",
+				Token:         "t0k3n",
+				APIURL:        "https://runner.circleci.com",
+			},
+			`agent:
+  runnerAPI: https://runner.circleci.com
+  resourceClasses:
+    acme/k8s:
+      token: t0k3n
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		rendered, err := renderRunnerAgentConfig(tc.config)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if rendered != tc.expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", tc.expected, rendered)
+		}
+	}
+}
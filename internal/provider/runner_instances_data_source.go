@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &RunnerInstancesDataSource{}
+
+func NewRunnerInstancesDataSource() datasource.DataSource {
+	return &RunnerInstancesDataSource{}
+}
+
+type RunnerInstancesDataSource struct {
+	client *CircleciAPIClient
+}
+
+type RunnerInstancesDataSourceModel struct {
+	Namespace     types.String          `tfsdk:"namespace"`
+	ResourceClass types.String          `tfsdk:"resource_class"`
+	Instances     []runnerInstanceModel `tfsdk:"instances"`
+	Id            types.String          `tfsdk:"id"`
+}
+
+type runnerInstanceModel struct {
+	ResourceClass  types.String `tfsdk:"resource_class"`
+	Hostname       types.String `tfsdk:"hostname"`
+	Name           types.String `tfsdk:"name"`
+	IP             types.String `tfsdk:"ip"`
+	Version        types.String `tfsdk:"version"`
+	FirstConnected types.String `tfsdk:"first_connected"`
+	LastConnected  types.String `tfsdk:"last_connected"`
+	LastUsed       types.String `tfsdk:"last_used"`
+}
+
+// runnerInstance is a runner agent, as returned by the runner API.
+type runnerInstance struct {
+	ResourceClass  string `json:"resource_class"`
+	Hostname       string `json:"hostname"`
+	Name           string `json:"name"`
+	IP             string `json:"ip"`
+	Version        string `json:"version"`
+	FirstConnected string `json:"first_connected"`
+	LastConnected  string `json:"last_connected"`
+	LastUsed       string `json:"last_used"`
+}
+
+func (d *RunnerInstancesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_runner_instances"
+}
+
+func (d *RunnerInstancesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Fetches the list of Runner instances (i.e., connected agents) for a specific namespace or Runner resource-class.",
+		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "CircleCI namespace. Either this or `resource_class` must be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("resource_class")),
+				},
+			},
+			"resource_class": schema.StringAttribute{
+				MarkdownDescription: "The name of the Runner resource-class (should include namespace). Either this or `namespace` must be set.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier of this data source: namespace or resource-class.",
+				Computed:            true,
+			},
+			"instances": schema.ListNestedAttribute{
+				MarkdownDescription: "List of Runner instances",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"resource_class": schema.StringAttribute{
+							MarkdownDescription: "The Runner resource-class name",
+							Computed:            true,
+						},
+						"hostname": schema.StringAttribute{
+							MarkdownDescription: "Hostname of the machine the Runner agent runs on",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the Runner agent",
+							Computed:            true,
+						},
+						"ip": schema.StringAttribute{
+							MarkdownDescription: "IP address of the Runner agent",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							MarkdownDescription: "Version of the Runner agent",
+							Computed:            true,
+						},
+						"first_connected": schema.StringAttribute{
+							MarkdownDescription: "Date and time the Runner agent first connected",
+							Computed:            true,
+						},
+						"last_connected": schema.StringAttribute{
+							MarkdownDescription: "Date and time the Runner agent last connected",
+							Computed:            true,
+						},
+						"last_used": schema.StringAttribute{
+							MarkdownDescription: "Date and time the Runner agent last ran a task",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RunnerInstancesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RunnerInstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RunnerInstancesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	if data.ResourceClass.ValueString() != "" {
+		query.Set("resource-class", data.ResourceClass.ValueString())
+		data.Id = data.ResourceClass
+	} else {
+		query.Set("namespace", data.Namespace.ValueString())
+		data.Id = data.Namespace
+	}
+
+	var info struct {
+		Items []runnerInstance `json:"items"`
+	}
+	if err := d.client.doRunnerJSON(ctx, http.MethodGet, "/v3/runner", query, &info); err != nil {
+		resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
+		return
+	}
+
+	data.Instances = []runnerInstanceModel{}
+	for _, ri := range info.Items {
+		instanceState := runnerInstanceModel{
+			ResourceClass:  types.StringValue(ri.ResourceClass),
+			Hostname:       types.StringValue(ri.Hostname),
+			Name:           types.StringValue(ri.Name),
+			IP:             types.StringValue(ri.IP),
+			Version:        types.StringValue(ri.Version),
+			FirstConnected: types.StringValue(ri.FirstConnected),
+			LastConnected:  types.StringValue(ri.LastConnected),
+			LastUsed:       types.StringValue(ri.LastUsed),
+		}
+		data.Instances = append(data.Instances, instanceState)
+	}
+
+	// Save data into Terraform state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRunnerInstancesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "circleci_runner_instances" "test" {
+  resource_class = "%s/%s"
+}`, namespace, resourceClass),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_runner_instances.test", "id", fmt.Sprintf("%s/%s", namespace, resourceClass)),
+					// no agents connected for the throwaway runner
+					resource.TestCheckResourceAttr("data.circleci_runner_instances.test", "instances.#", "0"),
+				),
+			},
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "circleci_runner_instances" "test" {
+  namespace = "%s"
+}`, namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_runner_instances.test", "id", namespace),
+					resource.TestCheckResourceAttrSet("data.circleci_runner_instances.test", "instances.#"),
+				),
+			},
+		},
+	})
+}
@@ -2,7 +2,9 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -21,11 +24,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/kelvintaywl/circleci-runner-go-sdk/client/resource_class"
+	"github.com/kelvintaywl/circleci-runner-go-sdk/client/token"
 	"github.com/kelvintaywl/circleci-runner-go-sdk/models"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &RunnerResourceClassResource{}
+var _ resource.ResourceWithModifyPlan = &RunnerResourceClassResource{}
 
 func NewRunnerResourceClassResource() resource.Resource {
 	return &RunnerResourceClassResource{}
@@ -39,6 +44,7 @@ type RunnerResourceClassResourceModel struct {
 	Id            types.String `tfsdk:"id"`
 	Description   types.String `tfsdk:"description"`
 	ResourceClass types.String `tfsdk:"resource_class"`
+	ForceDestroy  types.Bool   `tfsdk:"force_destroy"`
 }
 
 func (r *RunnerResourceClassResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -78,6 +84,12 @@ func (r *RunnerResourceClassResource) Schema(_ context.Context, _ resource.Schem
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"force_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to also delete the tokens of the Runner resource-class when destroying it (default: false). Otherwise, destroying a Runner resource-class with tokens fails.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -133,20 +145,71 @@ func (r *RunnerResourceClassResource) Read(ctx context.Context, req resource.Rea
 
 	info := res.GetPayload()
 	for _, rc := range info.Items {
-		if id == rc.ID.String() {
+		// resource-classes imported by name have no ID yet
+		if id == rc.ID.String() || (id == "" && *rc.ResourceClass == resourceClass) {
 			state.Id = types.StringValue(rc.ID.String())
 			state.Description = types.StringValue(*rc.Description)
 			state.ResourceClass = types.StringValue(*rc.ResourceClass)
 			// Set refreshed state
 			diags = resp.State.Set(ctx, &state)
 			resp.Diagnostics.Append(diags...)
-			break
+			return
 		}
 	}
 
+	if id == "" {
+		resp.Diagnostics.AddError(fmt.Sprintf("Did not find Runner resource-class %s", resourceClass), fmt.Sprintf("namespace %s", namespace))
+	}
+}
+
+// ModifyPlan warns about the tokens lost when replacing the resource-class,
+// since the runner API does not support updating resource-classes.
+func (r *RunnerResourceClassResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to replace when creating or destroying
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state RunnerResourceClassResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if plan.Description.Equal(state.Description) && plan.ResourceClass.Equal(state.ResourceClass) {
+		return
+	}
+
+	resourceClass := state.ResourceClass.ValueString()
+	param := token.NewListTokensParamsWithContext(ctx).WithDefaults()
+	param = param.WithResourceClass(resourceClass)
+
+	res, err := r.client.RunnerClient.Token.ListTokens(param, r.client.Auth)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("Could not list tokens of Runner resource-class %s", resourceClass),
+			fmt.Sprintf("Replacing the Runner resource-class also deletes its tokens. Unexpected error: %s", err.Error()),
+		)
+		return
+	}
+
+	var tokens []string
+	for _, tk := range res.GetPayload().Items {
+		tokens = append(tokens, fmt.Sprintf("- %s (%s)", *tk.Nickname, tk.ID.String()))
+	}
+	if len(tokens) == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		fmt.Sprintf("Replacing Runner resource-class %s deletes its tokens", resourceClass),
+		fmt.Sprintf("Runner resource-classes cannot be updated, and are replaced instead. "+
+			"Unless force_destroy is true, the replacement fails while the Runner resource-class has tokens. "+
+			"Runner agents using the following tokens will go offline until redeployed with new tokens:\n%s", strings.Join(tokens, "\n")),
+	)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -191,7 +254,59 @@ func (r *RunnerResourceClassResource) Create(ctx context.Context, req resource.C
 }
 
 func (r *RunnerResourceClassResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// not implemented; requires a replacement
+	// only force_destroy can change in-place; other changes require a replacement
+	var plan RunnerResourceClassResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// forceDelete deletes the resource-class together with its tokens.
+func (r *RunnerResourceClassResource) forceDelete(ctx context.Context, id string) error {
+	return r.client.doRunnerJSON(ctx, http.MethodDelete, fmt.Sprintf("/v3/runner/resource/%s/force", id), nil, nil)
+}
+
+// forceDeleteUnsupported returns true if force-deleting failed with an HTTP 404 or 405 response,
+// i.e., the endpoint is not supported on this CircleCI server.
+func forceDeleteUnsupported(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusMethodNotAllowed)
+}
+
+// deleteTokens deletes all tokens of the resource-class.
+func (r *RunnerResourceClassResource) deleteTokens(ctx context.Context, resourceClass string) error {
+	param := token.NewListTokensParamsWithContext(ctx).WithDefaults()
+	param = param.WithResourceClass(resourceClass)
+
+	res, err := r.client.RunnerClient.Token.ListTokens(param, r.client.Auth)
+	if err != nil {
+		return err
+	}
+
+	for _, tk := range res.GetPayload().Items {
+		id := tk.ID.String()
+		param := token.NewDeleteTokenParamsWithContext(ctx).WithDefaults()
+		param = param.WithID(strfmt.UUID(id))
+
+		_, err := r.client.RunnerClient.Token.DeleteToken(param, r.client.Auth)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				tflog.Warn(ctx, fmt.Sprintf("Runner token no longer found: %s", id))
+				continue
+			}
+			return fmt.Errorf("could not delete Runner token %s: %w", id, err)
+		}
+		tflog.Info(ctx, fmt.Sprintf("Deleted Runner token %s of resource-class %s", id, resourceClass))
+	}
+	return nil
 }
 
 func (r *RunnerResourceClassResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -205,6 +320,30 @@ func (r *RunnerResourceClassResource) Delete(ctx context.Context, req resource.D
 
 	id := state.Id.ValueString()
 
+	if state.ForceDestroy.ValueBool() {
+		err := r.forceDelete(ctx, id)
+		if err == nil {
+			return
+		}
+		if !forceDeleteUnsupported(err) {
+			resp.Diagnostics.AddError(
+				"Error deleting resource-class",
+				fmt.Sprintf("Could not force-delete resource-class %s, unexpected error: %s", id, err.Error()),
+			)
+			return
+		}
+
+		resourceClass := state.ResourceClass.ValueString()
+		tflog.Warn(ctx, fmt.Sprintf("Could not force-delete resource-class %s, deleting its tokens instead: %s", id, err.Error()))
+		if err := r.deleteTokens(ctx, resourceClass); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting resource-class tokens",
+				fmt.Sprintf("Could not delete tokens of resource-class %s, unexpected error: %s", resourceClass, err.Error()),
+			)
+			return
+		}
+	}
+
 	param := resource_class.NewDeleteResourceClassParamsWithContext(ctx).WithDefaults()
 	param = param.WithID(strfmt.UUID(id))
 
@@ -226,19 +365,19 @@ func (r *RunnerResourceClassResource) Delete(ctx context.Context, req resource.D
 func (r *RunnerResourceClassResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 
 	// resource class, ID
-	idParts := strings.Split(req.ID, ",")
+	// or resource class only, i.e., <namespace>/<name>
+	runnerResourceClass, id, withId := strings.Cut(req.ID, ",")
+	namespaceName := strings.SplitN(runnerResourceClass, "/", 2)
 
-	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+	if len(namespaceName) != 2 || namespaceName[0] == "" || namespaceName[1] == "" || (withId && (id == "" || strings.Contains(id, ","))) {
 		resp.Diagnostics.AddError(
 			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: resource_class,id. Got: %q", req.ID),
+			fmt.Sprintf("Expected import identifier with format: resource_class,id or resource_class. Got: %q", req.ID),
 		)
 		return
 	}
 
-	runnerResourceClass := idParts[0]
-	id := idParts[1]
-
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_class"), runnerResourceClass)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
 }
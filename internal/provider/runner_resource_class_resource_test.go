@@ -1,12 +1,31 @@
 package provider
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
+func TestForceDeleteUnsupported(t *testing.T) {
+	for _, tc := range []struct {
+		err      error
+		expected bool
+	}{
+		{&apiError{StatusCode: http.StatusNotFound}, true},
+		{&apiError{StatusCode: http.StatusMethodNotAllowed}, true},
+		{&apiError{StatusCode: http.StatusBadRequest}, false},
+		{&apiError{StatusCode: http.StatusInternalServerError}, false},
+		{errors.New("connection reset"), false},
+	} {
+		if got := forceDeleteUnsupported(tc.err); got != tc.expected {
+			t.Errorf("expected %v for %v, got %v", tc.expected, tc.err, got)
+		}
+	}
+}
+
 func TestAccRunnerResourceClassResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -32,6 +51,56 @@ resource "circleci_runner_resource_class" "blade_runner" {
 				ImportStateVerify:   true,
 				ImportStateIdPrefix: fmt.Sprintf("%s/acceptance-test,", namespace),
 			},
+			// Test Import by name
+			{
+				ResourceName:      "circleci_runner_resource_class.blade_runner",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("%s/acceptance-test", namespace),
+			},
+			// Update force_destroy in-place, with a token left on destroy
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_runner_resource_class" "blade_runner" {
+	resource_class = "%s/acceptance-test"
+	description    = "From Terraform acceptance test"
+	force_destroy  = true
+}
+
+resource "circleci_runner_token" "replicant" {
+	resource_class = circleci_runner_resource_class.blade_runner.resource_class
+	nickname       = "acceptance-test"
+}
+`, namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_runner_resource_class.blade_runner", "force_destroy", "true"),
+					resource.TestCheckResourceAttrSet("circleci_runner_token.replicant", "id"),
+				),
+			},
+			// Forget the token, so that it is left on CircleCI
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_runner_resource_class" "blade_runner" {
+	resource_class = "%s/acceptance-test"
+	description    = "From Terraform acceptance test"
+	force_destroy  = true
+}
+
+removed {
+	from = circleci_runner_token.replicant
+	lifecycle {
+		destroy = false
+	}
+}
+`, namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_runner_resource_class.blade_runner", "force_destroy", "true"),
+				),
+			},
+			// Destroy the resource-class, together with its token left
+			{
+				Config: providerConfig,
+			},
 		},
 	})
 }
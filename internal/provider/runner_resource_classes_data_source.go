@@ -3,9 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/kelvintaywl/circleci-runner-go-sdk/client/resource_class"
@@ -24,8 +26,12 @@ type RunnerResourceClassesDataSource struct {
 
 type RunnerResourceClassesDataSourceModel struct {
 	Namespace       types.String         `tfsdk:"namespace"`
+	Name            types.String         `tfsdk:"name"`
 	ResourceClasses []resourceClassModel `tfsdk:"resource_classes"`
 	Id              types.String         `tfsdk:"id"`
+	// the resource-class filtered by name, if any
+	ResourceClassId          types.String `tfsdk:"resource_class_id"`
+	ResourceClassDescription types.String `tfsdk:"resource_class_description"`
 }
 
 type resourceClassModel struct {
@@ -47,10 +53,22 @@ func (d *RunnerResourceClassesDataSource) Schema(ctx context.Context, req dataso
 				MarkdownDescription: "CircleCI namespace.",
 				Required:            true,
 			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only include the Runner resource-class of this name, with or without its namespace. It must exist.",
+				Optional:            true,
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Unique identifier of this data source: namespace.",
 				Computed:            true,
 			},
+			"resource_class_id": schema.StringAttribute{
+				MarkdownDescription: "The unique ID of the Runner resource-class, if filtered by name.",
+				Computed:            true,
+			},
+			"resource_class_description": schema.StringAttribute{
+				MarkdownDescription: "Description of the Runner resource-class, if filtered by name.",
+				Computed:            true,
+			},
 			"resource_classes": schema.ListNestedAttribute{
 				MarkdownDescription: "List of resource-classes",
 				Computed:            true,
@@ -114,8 +132,14 @@ func (d *RunnerResourceClassesDataSource) Read(ctx context.Context, req datasour
 		return
 	}
 
+	namespace := data.Namespace.ValueString()
+	name := strings.TrimPrefix(data.Name.ValueString(), namespace+"/")
+
 	info := res.GetPayload()
 	for _, rc := range info.Items {
+		if name != "" && *rc.ResourceClass != fmt.Sprintf("%s/%s", namespace, name) {
+			continue
+		}
 		resourceClassState := resourceClassModel{
 			Id:            types.StringValue(rc.ID.String()),
 			ResourceClass: types.StringValue(*rc.ResourceClass),
@@ -124,6 +148,20 @@ func (d *RunnerResourceClassesDataSource) Read(ctx context.Context, req datasour
 		data.ResourceClasses = append(data.ResourceClasses, resourceClassState)
 	}
 	data.Id = data.Namespace
+	data.ResourceClassId = types.StringNull()
+	data.ResourceClassDescription = types.StringNull()
+	if name != "" {
+		if len(data.ResourceClasses) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Runner resource-class not found",
+				fmt.Sprintf("Could not find Runner resource-class %s/%s", namespace, name),
+			)
+			return
+		}
+		data.ResourceClassId = data.ResourceClasses[0].Id
+		data.ResourceClassDescription = data.ResourceClasses[0].Description
+	}
 
 	// Save data into Terraform state
 	diags := resp.State.Set(ctx, &data)
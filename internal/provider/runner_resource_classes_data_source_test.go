@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -28,6 +29,29 @@ data "circleci_runner_resource_classes" "test" {
 					resource.TestCheckResourceAttr("data.circleci_runner_resource_classes.test", "resource_classes.0.description", "throwaway runner"),
 				),
 			},
+			// Filter by name
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "circleci_runner_resource_classes" "test" {
+  namespace = "%s"
+  name      = "%s"
+}`, namespace, resourceClass),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_runner_resource_classes.test", "id", namespace),
+					resource.TestCheckResourceAttr("data.circleci_runner_resource_classes.test", "resource_class_id", "1a24212b-7db4-493d-9469-1785e99af123"),
+					resource.TestCheckResourceAttr("data.circleci_runner_resource_classes.test", "resource_class_description", "throwaway runner"),
+					resource.TestCheckResourceAttr("data.circleci_runner_resource_classes.test", "resource_classes.#", "1"),
+					resource.TestCheckResourceAttr("data.circleci_runner_resource_classes.test", "resource_classes.0.resource_class", "kelvintaywl-tf/test"),
+				),
+			},
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "circleci_runner_resource_classes" "test" {
+  namespace = "%s"
+  name      = "does-not-exist"
+}`, namespace),
+				ExpectError: regexp.MustCompile("Runner resource-class not found"),
+			},
 		},
 	})
 }
@@ -5,14 +5,21 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-openapi/strfmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -21,10 +28,13 @@ import (
 
 	"github.com/kelvintaywl/circleci-runner-go-sdk/client/token"
 	"github.com/kelvintaywl/circleci-runner-go-sdk/models"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &RunnerTokenResource{}
+var _ resource.ResourceWithModifyPlan = &RunnerTokenResource{}
 
 func NewRunnerTokenResource() resource.Resource {
 	return &RunnerTokenResource{}
@@ -40,6 +50,15 @@ type RunnerTokenResourceModel struct {
 	ResourceClass types.String `tfsdk:"resource_class"`
 	Token         types.String `tfsdk:"token"`
 	CreatedAt     types.String `tfsdk:"created_at"`
+
+	PGPKey         types.String `tfsdk:"pgp_key"`
+	EncryptedToken types.String `tfsdk:"encrypted_token"`
+	KeyFingerprint types.String `tfsdk:"key_fingerprint"`
+
+	RotationTrigger types.Map    `tfsdk:"rotation_trigger"`
+	RotateAfter     types.String `tfsdk:"rotate_after"`
+
+	Imported types.Bool `tfsdk:"imported"`
 }
 
 func (r *RunnerTokenResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -80,13 +99,83 @@ func (r *RunnerTokenResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 			},
 			"token": schema.StringAttribute{
-				MarkdownDescription: "The Runner token value.",
+				MarkdownDescription: "The Runner token value. This is not set if `pgp_key` is set.",
 				Computed:            true,
 				Sensitive:           true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"pgp_key": schema.StringAttribute{
+				MarkdownDescription: "A PGP public key to encrypt the Runner token with, either base64-encoded (e.g., via `gpg --export <key-id> | base64`), armored, or as `keybase:<username>`, found in the provider's `pgp_keyring`. If set, only `encrypted_token` and `key_fingerprint` are set, and `token` is not. Required when the provider's `store_secrets_in_state` is false.",
+				Optional:            true,
+				// if modifed, this requires a replacement instead, unless first set on an imported token.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace, resp.Diagnostics = replaceUnlessImported(ctx, req.State, req.StateValue)
+						},
+						"Changing pgp_key replaces the Runner token, unless first set on an imported Runner token.",
+						"Changing `pgp_key` replaces the Runner token, unless first set on an imported Runner token.",
+					),
+				},
+			},
+			"encrypted_token": schema.StringAttribute{
+				MarkdownDescription: "The Runner token value, encrypted with `pgp_key` and base64-encoded. Decrypt it with e.g., `base64 --decode | gpg --decrypt`.",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_fingerprint": schema.StringAttribute{
+				MarkdownDescription: "The fingerprint of the PGP key used to encrypt the Runner token.",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotation_trigger": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, rotates the Runner token (i.e., replaces it).",
+				ElementType:         types.StringType,
+				Optional:            true,
+				// if modifed, this requires a replacement instead, unless first set on an imported token.
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace, resp.Diagnostics = replaceUnlessImported(ctx, req.State, req.StateValue)
+						},
+						"Changing rotation_trigger replaces the Runner token, unless first set on an imported Runner token.",
+						"Changing `rotation_trigger` replaces the Runner token, unless first set on an imported Runner token.",
+					),
+				},
+			},
+			"rotate_after": schema.StringAttribute{
+				MarkdownDescription: "Duration after its creation (e.g., `720h`) from which the Runner token is rotated (i.e., replaced) on the next apply.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`),
+						"must be a duration, e.g., 720h"),
+				},
+			},
+			"imported": schema.BoolAttribute{
+				MarkdownDescription: "Whether the Runner token was imported. Imported Runner tokens have no `token`, `encrypted_token` nor `key_fingerprint`, as the API only returns the token on creation.",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"created_at": schema.StringAttribute{
 				MarkdownDescription: "Date and time the token was created",
 				Computed:            true,
+				// unchanged even during updates, unless rotated
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -125,6 +214,7 @@ func (r *RunnerTokenResource) Read(ctx context.Context, req resource.ReadRequest
 
 	id := state.Id.ValueString()
 	resourceClass := state.ResourceClass.ValueString()
+	nickname := state.Nickname.ValueString()
 
 	param := token.NewListTokensParamsWithContext(ctx).WithDefaults()
 	param = param.WithResourceClass(resourceClass)
@@ -136,24 +226,102 @@ func (r *RunnerTokenResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	info := res.GetPayload()
+	matched := 0
 	for _, tk := range info.Items {
-		if id == tk.ID.String() {
+		// tokens imported by nickname have no ID yet
+		if id == tk.ID.String() || (id == "" && *tk.Nickname == nickname) {
+			matched++
 			state.Id = types.StringValue(tk.ID.String())
 			state.Nickname = types.StringValue(*tk.Nickname)
 			state.ResourceClass = types.StringValue(*tk.ResourceClass)
 			state.CreatedAt = types.StringValue(tk.CreatedAt.String())
-			// Set refreshed state
-			diags = resp.State.Set(ctx, &state)
-			resp.Diagnostics.Append(diags...)
-			break
 		}
 	}
 
+	if id == "" && matched != 1 {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Did not find a single Runner token with nickname %s", nickname),
+			fmt.Sprintf("Found %d Runner tokens with nickname %s for resource-class %s", matched, nickname, resourceClass),
+		)
+		return
+	}
+	if matched == 0 {
+		tflog.Warn(ctx, fmt.Sprintf("Runner token no longer found: %s", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// tokens from prior versions of this provider were all created
+	if state.Imported.IsNull() {
+		state.Imported = types.BoolValue(false)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
+// rotationDue returns true if the token created at createdAt is due for rotation at now.
+func rotationDue(createdAt string, rotateAfter string, now time.Time) (bool, error) {
+	d, err := time.ParseDuration(rotateAfter)
+	if err != nil {
+		return false, err
+	}
+	created, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return false, err
+	}
+	return !now.Before(created.Add(d)), nil
+}
+
+// ModifyPlan validates the PGP key, so that the token is never created without a way to encrypt it,
+// nor kept as-is in state when store_secrets_in_state is false.
+// It also plans a replacement of the token once it is older than rotate_after.
+func (r *RunnerTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planPGPKey(ctx, r.client, req, resp, "Runner token")
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// nothing to rotate when creating
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state RunnerTokenResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.RotateAfter.IsNull() || plan.RotateAfter.IsUnknown() || state.CreatedAt.ValueString() == "" {
+		return
+	}
+
+	due, err := rotationDue(state.CreatedAt.ValueString(), plan.RotateAfter.ValueString(), time.Now())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rotate_after"),
+			"Error checking Runner token rotation",
+			fmt.Sprintf("Could not check rotation of Runner token %s, unexpected error: %s", state.Id.ValueString(), err.Error()),
+		)
+		return
+	}
+	if !due {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Runner token %s is older than %s; planning rotation", state.Id.ValueString(), plan.RotateAfter.ValueString()))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("created_at"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("created_at"))
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *RunnerTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -164,6 +332,22 @@ func (r *RunnerTokenResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	// parse the PGP key first (as validated when planned, unless unknown then),
+	// so that the token is never created without a way to encrypt it.
+	var entity *openpgp.Entity
+	if plan.PGPKey.ValueString() != "" {
+		var err error
+		entity, err = parsePGPKey(plan.PGPKey.ValueString(), r.client.pgpKeyringFile())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("pgp_key"),
+				"Invalid PGP key",
+				fmt.Sprintf("Could not parse PGP key, unexpected error: %s", err.Error()),
+			)
+			return
+		}
+	}
+
 	param := token.NewCreateTokenParamsWithContext(ctx).WithDefaults()
 	resourceClass := plan.ResourceClass.ValueString()
 	nickname := plan.Nickname.ValueString()
@@ -186,8 +370,36 @@ func (r *RunnerTokenResource) Create(ctx context.Context, req resource.CreateReq
 
 	rc := res.GetPayload()
 	plan.Id = types.StringValue(rc.ID.String())
-	plan.Token = types.StringValue(rc.Token)
+	if entity != nil {
+		encrypted, err := encryptWithPGPKey(entity, rc.Token)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error encrypting Runner token",
+				fmt.Sprintf("Could not encrypt Runner token %s, unexpected error: %s", rc.ID.String(), err.Error()),
+			)
+			// the token is of no use without being stored, so it is not left behind
+			param := token.NewDeleteTokenParamsWithContext(ctx).WithDefaults()
+			param = param.WithID(*rc.ID)
+			if _, err := r.client.RunnerClient.Token.DeleteToken(param, r.client.Auth); err != nil {
+				resp.Diagnostics.AddError(
+					"Error deleting Runner token",
+					fmt.Sprintf("Could not delete unencrypted token %s, unexpected error: %s. Please delete it manually.", rc.ID.String(), err.Error()),
+				)
+			}
+			return
+		}
+		plan.Token = types.StringNull()
+		plan.EncryptedToken = types.StringValue(encrypted)
+		plan.KeyFingerprint = types.StringValue(pgpFingerprint(entity))
+	} else {
+		// generated tokens are of no use hashed, so they are kept as-is in state;
+		// pgp_key is required instead when store_secrets_in_state is false (see ModifyPlan).
+		plan.Token = types.StringValue(rc.Token)
+		plan.EncryptedToken = types.StringNull()
+		plan.KeyFingerprint = types.StringNull()
+	}
 	plan.CreatedAt = types.StringValue(rc.CreatedAt.String())
+	plan.Imported = types.BoolValue(false)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -197,8 +409,38 @@ func (r *RunnerTokenResource) Create(ctx context.Context, req resource.CreateReq
 	}
 }
 
+// replaceUnlessImported returns whether changing an attribute requires a replacement:
+// imported tokens have no prior value nor token to encrypt, so first setting it (e.g., pgp_key) does not.
+func replaceUnlessImported(ctx context.Context, state tfsdk.State, prior attr.Value) (bool, diag.Diagnostics) {
+	if !prior.IsNull() {
+		return true, nil
+	}
+	var imported types.Bool
+	diags := state.GetAttribute(ctx, path.Root("imported"), &imported)
+	return !imported.ValueBool(), diags
+}
+
 func (r *RunnerTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// not implemented; requires a replacement
+	// only rotate_after can change in-place, as well as pgp_key and rotation_trigger when first set on an imported token;
+	// other changes require a replacement
+	var plan, state RunnerTokenResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.RotateAfter = plan.RotateAfter
+	state.PGPKey = plan.PGPKey
+	state.RotationTrigger = plan.RotationTrigger
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 func (r *RunnerTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -229,3 +471,23 @@ func (r *RunnerTokenResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 }
+
+func (r *RunnerTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// <namespace>/<resource-class name>/<nickname>
+	idx := strings.LastIndex(req.ID, "/")
+	if idx <= 0 || idx == len(req.ID)-1 || !strings.Contains(req.ID[:idx], "/") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: resource_class/nickname. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resourceClass := req.ID[:idx]
+	nickname := req.ID[idx+1:]
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_class"), resourceClass)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("nickname"), nickname)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), "")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("imported"), true)...)
+}
@@ -1,9 +1,13 @@
 package provider
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -24,8 +28,115 @@ resource "circleci_runner_token" "delete_me" {
 					resource.TestCheckResourceAttr("circleci_runner_token.delete_me", "nickname", "acceptance-test"),
 					resource.TestCheckResourceAttrSet("circleci_runner_token.delete_me", "id"),
 					resource.TestCheckResourceAttrSet("circleci_runner_token.delete_me", "token"),
+					resource.TestCheckResourceAttr("circleci_runner_token.delete_me", "imported", "false"),
+				),
+			},
+			// Update rotate_after in-place
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_runner_token" "delete_me" {
+	resource_class = "%s/test"
+	nickname       = "acceptance-test"
+	rotate_after   = "720h"
+	lifecycle {
+		create_before_destroy = true
+	}
+}
+`, namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_runner_token.delete_me", "rotate_after", "720h"),
+					resource.TestCheckResourceAttrSet("circleci_runner_token.delete_me", "token"),
+				),
+			},
+			// Test Import by nickname
+			{
+				ResourceName:      "circleci_runner_token.delete_me",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("%s/test/acceptance-test", namespace),
+				// token is only returned on creation
+				ImportStateVerifyIgnore: []string{"token", "imported", "rotate_after"},
+			},
+			// Import by nickname again, keeping the imported state
+			{
+				ResourceName:       "circleci_runner_token.delete_me",
+				ImportState:        true,
+				ImportStatePersist: true,
+				ImportStateId:      fmt.Sprintf("%s/test/acceptance-test", namespace),
+			},
+			// Setting pgp_key and rotation_trigger on the imported token does not replace it
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "circleci_runner_token" "delete_me" {
+	resource_class = "%s/test"
+	nickname       = "acceptance-test"
+	rotate_after   = "720h"
+	pgp_key        = <<-EOT
+%s
+	EOT
+	rotation_trigger = {
+		version = "1"
+	}
+	lifecycle {
+		create_before_destroy = true
+	}
+}
+`, namespace, testPGPPublicKey(t)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_runner_token.delete_me", "imported", "true"),
+					resource.TestCheckResourceAttr("circleci_runner_token.delete_me", "rotation_trigger.version", "1"),
+					resource.TestCheckResourceAttrSet("circleci_runner_token.delete_me", "pgp_key"),
+					resource.TestCheckNoResourceAttr("circleci_runner_token.delete_me", "encrypted_token"),
 				),
 			},
 		},
 	})
 }
+
+// testPGPPublicKey returns a new armored PGP public key.
+func testPGPPublicKey(t *testing.T) string {
+	entity, err := openpgp.NewEntity("runner", "", "runner@example.com", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	armored := &bytes.Buffer{}
+	w, err := armor.Encode(armored, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	w.Close()
+	return armored.String()
+}
+
+func TestRunnerTokenRotationDue(t *testing.T) {
+	createdAt := "2023-06-01T00:00:00.000Z"
+	type testcase struct {
+		now         time.Time
+		rotateAfter string
+		expected    bool
+	}
+
+	testcases := []testcase{
+		{time.Date(2023, 6, 1, 23, 59, 59, 0, time.UTC), "24h", false},
+		{time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC), "24h", true},
+		{time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), "720h", true},
+		{time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC), "720h", false},
+	}
+
+	for _, tc := range testcases {
+		due, err := rotationDue(createdAt, tc.rotateAfter, tc.now)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if due != tc.expected {
+			t.Errorf("rotate_after %s at %s: expected due=%t, got %t", tc.rotateAfter, tc.now, tc.expected, due)
+		}
+	}
+
+	if _, err := rotationDue(createdAt, "a month", time.Now()); err == nil {
+		t.Errorf("expected error for invalid duration")
+	}
+}
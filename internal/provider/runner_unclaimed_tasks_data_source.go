@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &RunnerUnclaimedTasksDataSource{}
+
+func NewRunnerUnclaimedTasksDataSource() datasource.DataSource {
+	return &RunnerUnclaimedTasksDataSource{}
+}
+
+type RunnerUnclaimedTasksDataSource struct {
+	client *CircleciAPIClient
+}
+
+type RunnerUnclaimedTasksDataSourceModel struct {
+	ResourceClass      types.String `tfsdk:"resource_class"`
+	UnclaimedTaskCount types.Int64  `tfsdk:"unclaimed_task_count"`
+	RunningTaskCount   types.Int64  `tfsdk:"running_task_count"`
+	Id                 types.String `tfsdk:"id"`
+}
+
+func (d *RunnerUnclaimedTasksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_runner_unclaimed_tasks"
+}
+
+func (d *RunnerUnclaimedTasksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Fetches the number of unclaimed and running tasks for a specific Runner resource-class.",
+		Attributes: map[string]schema.Attribute{
+			"resource_class": schema.StringAttribute{
+				MarkdownDescription: "The name of the Runner resource-class (should include namespace)",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^.+\/.+`),
+						"must follow <namespace>/<name>"),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier of this data source: resource-class.",
+				Computed:            true,
+			},
+			"unclaimed_task_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of tasks waiting to be claimed by a Runner agent",
+				Computed:            true,
+			},
+			"running_task_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of tasks currently running on Runner agents",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RunnerUnclaimedTasksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RunnerUnclaimedTasksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RunnerUnclaimedTasksDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	query.Set("resource-class", data.ResourceClass.ValueString())
+
+	var unclaimed struct {
+		UnclaimedTaskCount int64 `json:"unclaimed_task_count"`
+	}
+	if err := d.client.doRunnerJSON(ctx, http.MethodGet, "/v3/runner/tasks", query, &unclaimed); err != nil {
+		resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
+		return
+	}
+
+	var running struct {
+		RunningRunnerTasks int64 `json:"running_runner_tasks"`
+	}
+	if err := d.client.doRunnerJSON(ctx, http.MethodGet, "/v3/runner/tasks/running", query, &running); err != nil {
+		resp.Diagnostics.AddError("Encountered error fetching API", fmt.Sprintf("%s", err))
+		return
+	}
+
+	data.Id = data.ResourceClass
+	data.UnclaimedTaskCount = types.Int64Value(unclaimed.UnclaimedTaskCount)
+	data.RunningTaskCount = types.Int64Value(running.RunningRunnerTasks)
+
+	// Save data into Terraform state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
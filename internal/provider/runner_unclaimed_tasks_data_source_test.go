@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRunnerUnclaimedTasksDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "circleci_runner_unclaimed_tasks" "test" {
+  resource_class = "%s/%s"
+}`, namespace, resourceClass),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_runner_unclaimed_tasks.test", "id", fmt.Sprintf("%s/%s", namespace, resourceClass)),
+					// no jobs run on the throwaway runner
+					resource.TestCheckResourceAttr("data.circleci_runner_unclaimed_tasks.test", "unclaimed_task_count", "0"),
+					resource.TestCheckResourceAttr("data.circleci_runner_unclaimed_tasks.test", "running_task_count", "0"),
+				),
+			},
+		},
+	})
+}
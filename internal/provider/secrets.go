@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// marks a salted hash of a secret in state, as "sha256:<salt>:<hash>"
+	secretHashPrefix string = "sha256:"
+	secretSaltSize   int    = 16
+)
+
+// hashSecret returns a salted hash of the secret, with a new random salt.
+func hashSecret(secret string) (string, error) {
+	salt := make([]byte, secretSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	return hashSecretWithSalt(secret, hex.EncodeToString(salt)), nil
+}
+
+func hashSecretWithSalt(secret, salt string) string {
+	sum := sha256.Sum256([]byte(salt + secret))
+	return fmt.Sprintf("%s%s:%s", secretHashPrefix, salt, hex.EncodeToString(sum[:]))
+}
+
+// isSecretHash returns true if the value is a salted hash of a secret, rather than a secret.
+func isSecretHash(value string) bool {
+	if !strings.HasPrefix(value, secretHashPrefix) {
+		return false
+	}
+	parts := strings.Split(strings.TrimPrefix(value, secretHashPrefix), ":")
+	return len(parts) == 2 && parts[0] != "" && parts[1] != ""
+}
+
+// secretMatchesHash returns true if the salted hash was computed from the secret.
+func secretMatchesHash(secret, hash string) bool {
+	if !isSecretHash(hash) {
+		return false
+	}
+	salt := strings.Split(strings.TrimPrefix(hash, secretHashPrefix), ":")[0]
+	return subtle.ConstantTimeCompare([]byte(hashSecretWithSalt(secret, salt)), []byte(hash)) == 1
+}
+
+// secretWriteOnlyAttribute returns the write-only alternative (e.g., value_wo) of a secret attribute (e.g., value).
+// It is never kept in state, and requires Terraform 1.11 or later.
+func secretWriteOnlyAttribute(name, description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("%s Write-only alternative to `%s`, never kept in state (requires Terraform 1.11 or later). Exactly one of `%s` or `%s_wo` must be set.", description, name, name, name),
+		Optional:            true,
+		Sensitive:           true,
+		WriteOnly:           true,
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot(name)),
+		},
+	}
+}
+
+// secretHashAttribute returns the computed salted hash of a write-only secret attribute (e.g., value_wo).
+func secretHashAttribute(name string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("A salted hash of `%s_wo`, to detect changes to it", name),
+		Computed:            true,
+	}
+}
+
+// configSecret returns the configured secret, from either the attribute or its write-only alternative.
+func configSecret(ctx context.Context, config tfsdk.Config, name string) (string, diag.Diagnostics) {
+	var secret, writeOnly types.String
+	diags := config.GetAttribute(ctx, path.Root(name), &secret)
+	diags.Append(config.GetAttribute(ctx, path.Root(name+"_wo"), &writeOnly)...)
+	if !writeOnly.IsNull() {
+		return writeOnly.ValueString(), diags
+	}
+	return secret.ValueString(), diags
+}
+
+// configSecretHash returns what to keep in state as the hash of a write-only secret:
+// the planned hash, if kept from state, or else a new salted hash of the configured secret.
+func configSecretHash(ctx context.Context, config tfsdk.Config, name string, planned types.String) (types.String, diag.Diagnostics) {
+	var writeOnly types.String
+	diags := config.GetAttribute(ctx, path.Root(name+"_wo"), &writeOnly)
+	if writeOnly.IsNull() || diags.HasError() {
+		return types.StringNull(), diags
+	}
+	if !planned.IsUnknown() && !planned.IsNull() {
+		return planned, diags
+	}
+	hash, err := hashSecret(writeOnly.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(name+"_hash"),
+			"Error hashing secret",
+			fmt.Sprintf("Could not hash %s_wo for state, unexpected error: %s", name, err.Error()),
+		)
+		return types.StringNull(), diags
+	}
+	return types.StringValue(hash), diags
+}
+
+// secretInState returns true if the secret is the one kept in state: either as-is
+// (e.g., in value, until moved to value_wo), or as the salted hash of the write-only attribute.
+func secretInState(secret string, priorSecret, priorHash types.String) bool {
+	if secretMatchesHash(secret, priorHash.ValueString()) {
+		return true
+	}
+	return !priorSecret.IsNull() && subtle.ConstantTimeCompare([]byte(secret), []byte(priorSecret.ValueString())) == 1
+}
+
+// planSecret plans the hash of a write-only secret (e.g., value_wo in value_hash), keeping the hash
+// in state while it matches the configured secret. A secret kept as-is in state is hashed
+// once moved to the write-only attribute, without being written again.
+// When store_secrets_in_state is false, secrets can only be configured via write-only attributes.
+// Other computed attributes which would only change because of the secret (e.g., updated_at) are also kept as-is from state.
+// It returns true if the configured secret differs from the one known in state.
+func planSecret(ctx context.Context, client *CircleciAPIClient, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, name string, volatilePaths ...path.Path) bool {
+	// nothing to plan when destroying
+	if req.Plan.Raw.IsNull() {
+		return false
+	}
+
+	secretPath := path.Root(name)
+	hashPath := path.Root(name + "_hash")
+
+	var secret, writeOnly types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, secretPath, &secret)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name+"_wo"), &writeOnly)...)
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+
+	if client != nil && client.hashSecrets && !secret.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			secretPath,
+			"Secret kept in state",
+			fmt.Sprintf("store_secrets_in_state is false, so set `%s_wo` instead of `%s`, to keep only a salted hash of the secret in state.", name, name),
+		)
+		return false
+	}
+
+	configured := secret
+	if writeOnly.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, hashPath, types.StringNull())...)
+	} else {
+		configured = writeOnly
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, hashPath, types.StringUnknown())...)
+	}
+
+	// nothing to compare when creating
+	if req.State.Raw.IsNull() || configured.IsUnknown() {
+		return !req.State.Raw.IsNull()
+	}
+
+	var priorSecret, priorHash types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, secretPath, &priorSecret)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, hashPath, &priorHash)...)
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+
+	if !secretInState(configured.ValueString(), priorSecret, priorHash) {
+		// unknown (e.g., imported) secrets are written on the next apply
+		return !priorSecret.IsNull() || !priorHash.IsNull()
+	}
+
+	if !writeOnly.IsNull() && secretMatchesHash(writeOnly.ValueString(), priorHash.ValueString()) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, hashPath, priorHash)...)
+	}
+
+	candidate := resp.Plan
+	for _, p := range volatilePaths {
+		var v types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &v)...)
+		resp.Diagnostics.Append(candidate.SetAttribute(ctx, p, v)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return false
+	}
+	if candidate.Raw.Equal(req.State.Raw) {
+		resp.Plan = candidate
+	}
+	return false
+}
@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSecretHash(t *testing.T) {
+	hash, err := hashSecret("s3cr3t")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !isSecretHash(hash) {
+		t.Errorf("expected %q to be a secret hash", hash)
+	}
+	if !secretMatchesHash("s3cr3t", hash) {
+		t.Errorf("expected %q to match its secret", hash)
+	}
+	if secretMatchesHash("s3cr3t!", hash) {
+		t.Errorf("expected %q not to match another secret", hash)
+	}
+
+	// salted: hashing the same secret twice gives different hashes
+	other, _ := hashSecret("s3cr3t")
+	if other == hash {
+		t.Errorf("expected different salts, got %q twice", hash)
+	}
+
+	for _, value := range []string{"", "s3cr3t", "sha256:", "sha256:salt", "sha256::hash"} {
+		if isSecretHash(value) {
+			t.Errorf("expected %q not to be a secret hash", value)
+		}
+		if secretMatchesHash(value, value) {
+			t.Errorf("expected %q not to match itself", value)
+		}
+	}
+}
+
+func TestSecretInState(t *testing.T) {
+	hash, _ := hashSecret("s3cr3t")
+	for _, tc := range []struct {
+		name        string
+		priorSecret types.String
+		priorHash   types.String
+		expected    bool
+	}{
+		{"unknown (e.g., imported)", types.StringNull(), types.StringNull(), false},
+		{"as-is", types.StringValue("s3cr3t"), types.StringNull(), true},
+		{"other as-is", types.StringValue("an0th3r"), types.StringNull(), false},
+		{"hashed", types.StringNull(), types.StringValue(hash), true},
+		{"hash as-is", types.StringValue(hash), types.StringNull(), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := secretInState("s3cr3t", tc.priorSecret, tc.priorHash); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// testSecretSchema is a minimal schema of a resource with a write-only secret.
+var testSecretSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"value":      schema.StringAttribute{Optional: true, Sensitive: true},
+		"value_wo":   secretWriteOnlyAttribute("value", "The value."),
+		"value_hash": secretHashAttribute("value"),
+		"updated_at": schema.StringAttribute{Computed: true},
+	},
+}
+
+func testSecretValue(t *testing.T, value, valueWO, valueHash, updatedAt tftypes.Value) tftypes.Value {
+	t.Helper()
+	return tftypes.NewValue(testSecretSchema.Type().TerraformType(context.Background()), map[string]tftypes.Value{
+		"value":      value,
+		"value_wo":   valueWO,
+		"value_hash": valueHash,
+		"updated_at": updatedAt,
+	})
+}
+
+func TestPlanSecret(t *testing.T) {
+	ctx := context.Background()
+	hash, _ := hashSecret("s3cr3t")
+
+	null := tftypes.NewValue(tftypes.String, nil)
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	str := func(s string) tftypes.Value { return tftypes.NewValue(tftypes.String, s) }
+
+	for _, tc := range []struct {
+		name        string
+		hashSecrets bool
+		config      tftypes.Value
+		state       tftypes.Value
+		// planned by Terraform, before planSecret
+		plan tftypes.Value
+
+		expectedChanged bool
+		expectedError   bool
+		expectedHash    types.String
+		expectedUpdated types.String
+	}{
+		{
+			name:            "unchanged hash",
+			hashSecrets:     true,
+			config:          testSecretValue(t, null, str("s3cr3t"), null, null),
+			state:           testSecretValue(t, null, null, str(hash), str("t1")),
+			plan:            testSecretValue(t, null, null, unknown, unknown),
+			expectedHash:    types.StringValue(hash),
+			expectedUpdated: types.StringValue("t1"),
+		},
+		{
+			name:            "changed hash",
+			hashSecrets:     true,
+			config:          testSecretValue(t, null, str("an0th3r"), null, null),
+			state:           testSecretValue(t, null, null, str(hash), str("t1")),
+			plan:            testSecretValue(t, null, null, unknown, unknown),
+			expectedChanged: true,
+			expectedHash:    types.StringUnknown(),
+			expectedUpdated: types.StringUnknown(),
+		},
+		{
+			// upgrade: the secret kept as-is in state is hashed once moved to value_wo
+			name:            "moved to write-only",
+			hashSecrets:     true,
+			config:          testSecretValue(t, null, str("s3cr3t"), null, null),
+			state:           testSecretValue(t, str("s3cr3t"), null, null, str("t1")),
+			plan:            testSecretValue(t, null, null, unknown, unknown),
+			expectedHash:    types.StringUnknown(),
+			expectedUpdated: types.StringUnknown(),
+		},
+		{
+			name:            "changed when moved to write-only",
+			hashSecrets:     true,
+			config:          testSecretValue(t, null, str("an0th3r"), null, null),
+			state:           testSecretValue(t, str("s3cr3t"), null, null, str("t1")),
+			plan:            testSecretValue(t, null, null, unknown, unknown),
+			expectedChanged: true,
+			expectedHash:    types.StringUnknown(),
+			expectedUpdated: types.StringUnknown(),
+		},
+		{
+			name:            "imported",
+			hashSecrets:     true,
+			config:          testSecretValue(t, null, str("s3cr3t"), null, null),
+			state:           testSecretValue(t, null, null, null, str("t1")),
+			plan:            testSecretValue(t, null, null, unknown, unknown),
+			expectedHash:    types.StringUnknown(),
+			expectedUpdated: types.StringUnknown(),
+		},
+		{
+			name:            "as-is",
+			config:          testSecretValue(t, str("s3cr3t"), null, null, null),
+			state:           testSecretValue(t, str("s3cr3t"), null, null, str("t1")),
+			plan:            testSecretValue(t, str("s3cr3t"), null, null, str("t1")),
+			expectedHash:    types.StringNull(),
+			expectedUpdated: types.StringValue("t1"),
+		},
+		{
+			name:          "as-is when store_secrets_in_state is false",
+			hashSecrets:   true,
+			config:        testSecretValue(t, str("s3cr3t"), null, null, null),
+			state:         testSecretValue(t, str("s3cr3t"), null, null, str("t1")),
+			plan:          testSecretValue(t, str("s3cr3t"), null, null, str("t1")),
+			expectedError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &CircleciAPIClient{hashSecrets: tc.hashSecrets}
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: testSecretSchema, Raw: tc.config},
+				State:  tfsdk.State{Schema: testSecretSchema, Raw: tc.state},
+				Plan:   tfsdk.Plan{Schema: testSecretSchema, Raw: tc.plan},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			changed := planSecret(ctx, client, req, resp, "value", path.Root("updated_at"))
+			if resp.Diagnostics.HasError() != tc.expectedError {
+				t.Fatalf("expected error: %t, got %v", tc.expectedError, resp.Diagnostics)
+			}
+			if tc.expectedError {
+				return
+			}
+			if changed != tc.expectedChanged {
+				t.Errorf("expected changed: %t, got %t", tc.expectedChanged, changed)
+			}
+
+			var planned, updated types.String
+			resp.Plan.GetAttribute(ctx, path.Root("value_hash"), &planned)
+			resp.Plan.GetAttribute(ctx, path.Root("updated_at"), &updated)
+			if !planned.Equal(tc.expectedHash) {
+				t.Errorf("expected value_hash %s, got %s", tc.expectedHash, planned)
+			}
+			if !updated.Equal(tc.expectedUpdated) {
+				t.Errorf("expected updated_at %s, got %s", tc.expectedUpdated, updated)
+			}
+		})
+	}
+}
+
+func TestConfigSecretHash(t *testing.T) {
+	ctx := context.Background()
+	null := tftypes.NewValue(tftypes.String, nil)
+	config := tfsdk.Config{
+		Schema: testSecretSchema,
+		Raw:    testSecretValue(t, null, tftypes.NewValue(tftypes.String, "s3cr3t"), null, null),
+	}
+
+	v, diags := configSecretHash(ctx, config, "value", types.StringUnknown())
+	if diags.HasError() || !secretMatchesHash("s3cr3t", v.ValueString()) {
+		t.Errorf("expected hash of secret in state, got %q (%v)", v.ValueString(), diags)
+	}
+
+	// a planned hash (kept from state) is kept as-is
+	kept, _ := configSecretHash(ctx, config, "value", v)
+	if kept != v {
+		t.Errorf("expected planned hash %q to be kept, got %q", v.ValueString(), kept.ValueString())
+	}
+
+	// no hash of secrets set as-is
+	config.Raw = testSecretValue(t, tftypes.NewValue(tftypes.String, "s3cr3t"), null, null, null)
+	none, _ := configSecretHash(ctx, config, "value", types.StringUnknown())
+	if !none.IsNull() {
+		t.Errorf("expected no hash, got %q", none.ValueString())
+	}
+}
@@ -0,0 +1,390 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &TriggerResource{}
+
+func NewTriggerResource() resource.Resource {
+	return &TriggerResource{}
+}
+
+type TriggerResource struct {
+	client *CircleciAPIClient
+}
+
+type TriggerResourceModel struct {
+	Id                        types.String `tfsdk:"id"`
+	ProjectID                 types.String `tfsdk:"project_id"`
+	PipelineDefinitionID      types.String `tfsdk:"pipeline_definition_id"`
+	Name                      types.String `tfsdk:"name"`
+	Description               types.String `tfsdk:"description"`
+	CreatedAt                 types.String `tfsdk:"created_at"`
+	EventSourceProvider       types.String `tfsdk:"event_source_provider"`
+	EventSourceRepoExternalID types.String `tfsdk:"event_source_repo_external_id"`
+	EventSourceRepoFullName   types.String `tfsdk:"event_source_repo_full_name"`
+	EventSourceWebhookURL     types.String `tfsdk:"event_source_webhook_url"`
+	EventPreset               types.String `tfsdk:"event_preset"`
+	CheckoutRef               types.String `tfsdk:"checkout_ref"`
+	ConfigRef                 types.String `tfsdk:"config_ref"`
+}
+
+type triggerEventSource struct {
+	Provider string      `json:"provider,omitempty"`
+	Repo     *sourceRepo `json:"repo,omitempty"`
+	Webhook  *struct {
+		URL string `json:"url,omitempty"`
+	} `json:"webhook,omitempty"`
+}
+
+// trigger is a trigger of a pipeline definition, in the v2 API.
+type trigger struct {
+	ID          string              `json:"id,omitempty"`
+	Name        string              `json:"name,omitempty"`
+	Description *string             `json:"description,omitempty"`
+	CreatedAt   string              `json:"created_at,omitempty"`
+	EventSource *triggerEventSource `json:"event_source,omitempty"`
+	EventPreset string              `json:"event_preset,omitempty"`
+	CheckoutRef string              `json:"checkout_ref,omitempty"`
+	ConfigRef   string              `json:"config_ref,omitempty"`
+}
+
+func (m *TriggerResourceModel) fromAPI(t trigger) {
+	m.Id = types.StringValue(t.ID)
+	m.Name = types.StringValue(t.Name)
+	m.Description = types.StringValue("")
+	if t.Description != nil {
+		m.Description = types.StringValue(*t.Description)
+	}
+	m.CreatedAt = types.StringValue(t.CreatedAt)
+	m.EventSourceRepoFullName = types.StringValue("")
+	m.EventSourceWebhookURL = types.StringValue("")
+	if s := t.EventSource; s != nil {
+		m.EventSourceProvider = types.StringValue(s.Provider)
+		if s.Repo != nil {
+			m.EventSourceRepoExternalID = types.StringValue(s.Repo.ExternalID)
+			m.EventSourceRepoFullName = types.StringValue(s.Repo.FullName)
+		}
+		if s.Webhook != nil {
+			m.EventSourceWebhookURL = types.StringValue(s.Webhook.URL)
+		}
+	}
+	m.EventPreset = types.StringValue(t.EventPreset)
+	m.CheckoutRef = types.StringValue(t.CheckoutRef)
+	m.ConfigRef = types.StringValue(t.ConfigRef)
+}
+
+func (r *TriggerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trigger"
+}
+
+func (r *TriggerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a trigger of a pipeline definition (for GitLab or GitHub App organizations)",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Read-only unique identifier",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The unique ID of the project",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"pipeline_definition_id": schema.StringAttribute{
+				MarkdownDescription: "The unique ID of the pipeline definition to trigger",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the trigger",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the trigger",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Date-time this trigger was created",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"event_source_provider": schema.StringAttribute{
+				MarkdownDescription: "Provider of the events triggering pipelines (e.g., `github_app` or `webhook`)",
+				Required:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"event_source_repo_external_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the repository whose events trigger pipelines, as assigned by its provider",
+				Optional:            true,
+				// if modifed, this requires a replacement instead.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"event_source_repo_full_name": schema.StringAttribute{
+				MarkdownDescription: "Full name of the repository whose events trigger pipelines",
+				Computed:            true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"event_source_webhook_url": schema.StringAttribute{
+				MarkdownDescription: "URL to send events to, for `webhook` event sources",
+				Computed:            true,
+				Sensitive:           true,
+				// unchanged even during updates
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"event_preset": schema.StringAttribute{
+				MarkdownDescription: "Preset filtering the events triggering pipelines (e.g., `all-pushes` or `only-open-prs`). Defaults to the CircleCI default for the event source.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"checkout_ref": schema.StringAttribute{
+				MarkdownDescription: "Git ref (e.g., branch) checked out by the triggered pipelines. Defaults to the ref of the event.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"config_ref": schema.StringAttribute{
+				MarkdownDescription: "Git ref (e.g., branch) to fetch the config from. Defaults to the ref of the event.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *TriggerResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*CircleciAPIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *CircleciAPIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TriggerResource) url(projectID, id string) string {
+	return r.client.apiURL(fmt.Sprintf("/api/v2/projects/%s/triggers/%s", projectID, id))
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *TriggerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state TriggerResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.Id.ValueString()
+	var t trigger
+	err := r.client.doJSON(ctx, http.MethodGet, r.url(state.ProjectID.ValueString(), id), nil, &t)
+	if isNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Trigger %s no longer exists; removing from state.", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Encountered error reading Trigger %s", id), fmt.Sprintf("%s", err))
+		return
+	}
+
+	externalID := state.EventSourceRepoExternalID
+	state.fromAPI(t)
+	// webhook event sources have no repository
+	if externalID.IsNull() && state.EventSourceRepoExternalID.ValueString() == "" {
+		state.EventSourceRepoExternalID = externalID
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *TriggerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan TriggerResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	description := plan.Description.ValueString()
+	body := trigger{
+		Name:        plan.Name.ValueString(),
+		Description: &description,
+		EventSource: &triggerEventSource{
+			Provider: plan.EventSourceProvider.ValueString(),
+		},
+		EventPreset: plan.EventPreset.ValueString(),
+		CheckoutRef: plan.CheckoutRef.ValueString(),
+		ConfigRef:   plan.ConfigRef.ValueString(),
+	}
+	if !plan.EventSourceRepoExternalID.IsNull() {
+		body.EventSource.Repo = &sourceRepo{ExternalID: plan.EventSourceRepoExternalID.ValueString()}
+	}
+
+	url := r.client.apiURL(fmt.Sprintf(
+		"/api/v2/projects/%s/pipeline-definitions/%s/triggers",
+		plan.ProjectID.ValueString(),
+		plan.PipelineDefinitionID.ValueString(),
+	))
+	var t trigger
+	if err := r.client.doJSON(ctx, http.MethodPost, url, body, &t); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating trigger",
+			fmt.Sprintf("Could not create trigger, unexpected error: %s", err.Error()),
+		)
+		return
+	}
+
+	externalID := plan.EventSourceRepoExternalID
+	plan.fromAPI(t)
+	plan.EventSourceRepoExternalID = externalID
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *TriggerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan TriggerResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := plan.Id.ValueString()
+	description := plan.Description.ValueString()
+	body := trigger{
+		Name:        plan.Name.ValueString(),
+		Description: &description,
+		EventPreset: plan.EventPreset.ValueString(),
+		CheckoutRef: plan.CheckoutRef.ValueString(),
+		ConfigRef:   plan.ConfigRef.ValueString(),
+	}
+
+	var t trigger
+	if err := r.client.doJSON(ctx, http.MethodPatch, r.url(plan.ProjectID.ValueString(), id), body, &t); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating trigger",
+			fmt.Sprintf("Could not update trigger %s, unexpected error: %s", id, err.Error()),
+		)
+		return
+	}
+
+	externalID := plan.EventSourceRepoExternalID
+	plan.fromAPI(t)
+	plan.EventSourceRepoExternalID = externalID
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *TriggerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TriggerResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.Id.ValueString()
+	err := r.client.doJSON(ctx, http.MethodDelete, r.url(state.ProjectID.ValueString(), id), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting trigger",
+			fmt.Sprintf("Could not delete trigger %s, unexpected error: %s", id, err.Error()),
+		)
+		return
+	}
+}
+
+func (r *TriggerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_id/pipeline_definition_id/trigger_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pipeline_definition_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[2])...)
+}
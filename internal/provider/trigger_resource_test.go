@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestTriggerFromAPI(t *testing.T) {
+	payload := `{
+		"id": "a2b2ca2d-0f5a-4dcc-a9b3-e2a8b0ad9e7c",
+		"created_at": "2024-08-15T12:00:00Z",
+		"event_source": {"provider": "webhook", "webhook": {"url": "https://internal.circleci.com/private/soc/e/abc?secret=s3cr3t"}},
+		"event_preset": "",
+		"checkout_ref": "main",
+		"config_ref": "main"
+	}`
+	var tr trigger
+	if err := json.Unmarshal([]byte(payload), &tr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// as planned, before being applied
+	m := TriggerResourceModel{
+		EventSourceRepoExternalID: types.StringNull(),
+		EventSourceRepoFullName:   types.StringUnknown(),
+		EventSourceWebhookURL:     types.StringUnknown(),
+	}
+	m.fromAPI(tr)
+
+	if m.EventSourceProvider.ValueString() != "webhook" {
+		t.Errorf("expected event source provider webhook, got %q", m.EventSourceProvider.ValueString())
+	}
+	if m.EventSourceWebhookURL.ValueString() != "https://internal.circleci.com/private/soc/e/abc?secret=s3cr3t" {
+		t.Errorf("unexpected webhook URL %q", m.EventSourceWebhookURL.ValueString())
+	}
+	if !m.EventSourceRepoExternalID.IsNull() {
+		t.Errorf("expected no repository, got %q", m.EventSourceRepoExternalID.ValueString())
+	}
+	// computed, so it must be known once applied
+	if m.EventSourceRepoFullName.IsUnknown() || m.EventSourceRepoFullName.ValueString() != "" {
+		t.Errorf("expected no repository full name, got %s", m.EventSourceRepoFullName)
+	}
+	if m.Description.ValueString() != "" || m.CheckoutRef.ValueString() != "main" {
+		t.Errorf("unexpected description %q or checkout ref %q", m.Description.ValueString(), m.CheckoutRef.ValueString())
+	}
+}
+
+func TestAccTriggerResource(t *testing.T) {
+	externalID := testAccRepoExternalID(t)
+	config := func(preset string) string {
+		return providerConfig + fmt.Sprintf(`
+resource "circleci_pipeline_definition" "pd1" {
+	project_id                       = "%s"
+	name                             = "tf-acceptance-test-trigger"
+	config_source_provider           = "github_app"
+	config_source_repo_external_id   = "%s"
+	config_source_file_path          = ".circleci/config.yml"
+	checkout_source_provider         = "github_app"
+	checkout_source_repo_external_id = "%s"
+}
+
+resource "circleci_trigger" "t1" {
+	project_id                    = circleci_pipeline_definition.pd1.project_id
+	pipeline_definition_id        = circleci_pipeline_definition.pd1.id
+	name                          = "on-push"
+	event_source_provider         = "github_app"
+	event_source_repo_external_id = "%s"
+	event_preset                  = "%s"
+}
+`, standaloneProjectId, externalID, externalID, externalID, preset)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config("all-pushes"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("circleci_trigger.t1", "id"),
+					resource.TestCheckResourceAttr("circleci_trigger.t1", "event_preset", "all-pushes"),
+					resource.TestCheckResourceAttrSet("circleci_trigger.t1", "event_source_repo_full_name"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName: "circleci_trigger.t1",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["circleci_trigger.t1"]
+					return fmt.Sprintf(
+						"%s/%s/%s",
+						rs.Primary.Attributes["project_id"],
+						rs.Primary.Attributes["pipeline_definition_id"],
+						rs.Primary.ID,
+					), nil
+				},
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: config("only-tags"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_trigger.t1", "event_preset", "only-tags"),
+				),
+			},
+		},
+	})
+}
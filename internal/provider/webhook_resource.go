@@ -27,6 +27,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &WebhookResource{}
+var _ resource.ResourceWithModifyPlan = &WebhookResource{}
 
 func NewWebhookResource() resource.Resource {
 	return &WebhookResource{}
@@ -37,15 +38,17 @@ type WebhookResource struct {
 }
 
 type WebhookResourceModel struct {
-	Id            types.String `tfsdk:"id"`
-	CreatedAt     types.String `tfsdk:"created_at"`
-	UpdatedAt     types.String `tfsdk:"updated_at"`
-	Name          types.String `tfsdk:"name"`
-	URL           types.String `tfsdk:"url"`
-	SigningSecret types.String `tfsdk:"signing_secret"`
-	ProjectID     types.String `tfsdk:"project_id"`
-	VerifyTLS     types.Bool   `tfsdk:"verify_tls"`
-	Events        types.Set    `tfsdk:"events"`
+	Id                types.String `tfsdk:"id"`
+	CreatedAt         types.String `tfsdk:"created_at"`
+	UpdatedAt         types.String `tfsdk:"updated_at"`
+	Name              types.String `tfsdk:"name"`
+	URL               types.String `tfsdk:"url"`
+	SigningSecret     types.String `tfsdk:"signing_secret"`
+	SigningSecretWO   types.String `tfsdk:"signing_secret_wo"`
+	SigningSecretHash types.String `tfsdk:"signing_secret_hash"`
+	ProjectID         types.String `tfsdk:"project_id"`
+	VerifyTLS         types.Bool   `tfsdk:"verify_tls"`
+	Events            types.Set    `tfsdk:"events"`
 }
 
 var vEvents = []string{
@@ -95,9 +98,11 @@ func (r *WebhookResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 			},
 			"signing_secret": schema.StringAttribute{
 				MarkdownDescription: "Secret used to build an HMAC hash of the payload and passed as a header in the webhook request",
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"signing_secret_wo":   secretWriteOnlyAttribute("signing_secret", "Secret used to build an HMAC hash of the payload and passed as a header in the webhook request."),
+			"signing_secret_hash": secretHashAttribute("signing_secret"),
 			"project_id": schema.StringAttribute{
 				MarkdownDescription: "ID of the project",
 				Required:            true,
@@ -186,6 +191,11 @@ func (r *WebhookResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 }
 
+// ModifyPlan keeps the hashed signing secret (see signing_secret_wo) in the plan while it matches the configuration.
+func (r *WebhookResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planSecret(ctx, r.client, req, resp, "signing_secret", path.Root("updated_at"))
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -196,6 +206,12 @@ func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	signingSecret, diags := configSecret(ctx, req.Config, "signing_secret")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	param := webhook.NewAddWebhookParamsWithContext(ctx).WithDefaults()
 	project := "project"
 	scope := models.WebhookBasePayloadScope{
@@ -209,7 +225,7 @@ func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest
 			URL:   plan.URL.ValueString(),
 			Scope: &scope,
 		},
-		SigningSecret: plan.SigningSecret.ValueString(),
+		SigningSecret: signingSecret,
 		VerifyTLS:     &verifyTLS,
 	}
 
@@ -229,6 +245,12 @@ func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest
 
 	w := res.GetPayload()
 
+	plan.SigningSecretHash, diags = configSecretHash(ctx, req.Config, "signing_secret", plan.SigningSecretHash)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	id := w.ID.String()
 	plan.Id = types.StringValue(id)
 	plan.CreatedAt = types.StringValue(w.CreatedAt.String())
@@ -253,6 +275,12 @@ func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	id := plan.Id.ValueString()
 
+	signingSecret, diags := configSecret(ctx, req.Config, "signing_secret")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	param := webhook.NewUpdateWebhookParamsWithContext(ctx).WithDefaults()
 	param = param.WithID(strfmt.UUID(id))
 	project := "project"
@@ -267,7 +295,7 @@ func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest
 			URL:   plan.URL.ValueString(),
 			Scope: &scope,
 		},
-		SigningSecret: plan.SigningSecret.ValueString(),
+		SigningSecret: signingSecret,
 		VerifyTLS:     &verifyTLS,
 	}
 	var events []string
@@ -286,6 +314,12 @@ func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	w := res.GetPayload()
 
+	plan.SigningSecretHash, diags = configSecretHash(ctx, req.Config, "signing_secret", plan.SigningSecretHash)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// NOTE: no need to update ID and ProjectID
 	plan.CreatedAt = types.StringValue(w.CreatedAt.String())
 	plan.UpdatedAt = types.StringValue(w.UpdatedAt.String())